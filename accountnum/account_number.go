@@ -0,0 +1,76 @@
+package accountnum
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// account numbers are 9 random digits followed by a luhn check digit
+const (
+	length        = 10
+	payloadLength = length - 1
+	//tries before giving up on finding a free number
+	maxAttempts = 20
+)
+
+// function to compute the luhn check digit of the payload digits
+func checkDigit(payload string) byte {
+	sum := 0
+	double := true
+	for i := len(payload) - 1; i >= 0; i-- {
+		d := int(payload[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// function to check the format and check digit of an account number
+// a single mistyped digit always fails this, and so do two swapped neighbours
+// except 09 and 90, which luhn can't tell apart
+func Valid(number string) bool {
+	if len(number) != length {
+		return false
+	}
+	for i := 0; i < length; i++ {
+		if number[i] < '0' || number[i] > '9' {
+			return false
+		}
+	}
+	return checkDigit(number[:payloadLength]) == number[payloadLength]
+}
+
+// function to describe why an account number got rejected, nil if it is fine
+func Validate(number string) error {
+	if !Valid(number) {
+		return fmt.Errorf("Invalid account number %q: wrong length or check digit", number)
+	}
+	return nil
+}
+
+// function to allocate a fresh account number, isTaken tells which numbers already exist
+func Allocate(isTaken func(string) bool) (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(payloadLength), nil)
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", fmt.Errorf("Failed to generate account number: %v", err)
+		}
+
+		payload := fmt.Sprintf("%0*d", payloadLength, n)
+		number := payload + string(checkDigit(payload))
+		if !isTaken(number) {
+			return number, nil
+		}
+	}
+
+	return "", fmt.Errorf("Failed to find a free account number after %d attempts", maxAttempts)
+}
@@ -0,0 +1,79 @@
+package accountnum
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckDigit(t *testing.T) {
+	cases := []struct {
+		payload string
+		want    byte
+	}{
+		{"000000000", '0'},
+		{"123456789", '7'},
+		{"799273987", '5'},
+		{"100000009", '9'},
+		{"909000000", '2'},
+	}
+
+	for _, c := range cases {
+		if got := checkDigit(c.payload); got != c.want {
+			t.Errorf("checkDigit(%s) = %c, want %c", c.payload, got, c.want)
+		}
+	}
+}
+
+func TestValid(t *testing.T) {
+	cases := []struct {
+		name   string
+		number string
+		want   bool
+	}{
+		{"good", "1234567897", true},
+		{"all zeros", "0000000000", true},
+		{"wrong check digit", "1234567898", false},
+		{"one mistyped digit", "1234567807", false},
+		{"swapped neighbours", "2134567897", false},
+		//the one swap luhn misses
+		{"09 swapped to 90", "0990000002", true},
+		{"too short", "123456789", false},
+		{"too long", "12345678970", false},
+		{"not a digit", "12345a7897", false},
+		{"empty", "", false},
+	}
+
+	for _, c := range cases {
+		if got := Valid(c.number); got != c.want {
+			t.Errorf("%s: Valid(%q) = %v, want %v", c.name, c.number, got, c.want)
+		}
+	}
+}
+
+func TestAllocateGivesValidFreeNumbers(t *testing.T) {
+	taken := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		number, err := Allocate(func(n string) bool { return taken[n] })
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !Valid(number) || taken[number] {
+			t.Fatalf("allocated %s, valid %v, already taken %v", number, Valid(number), taken[number])
+		}
+		taken[number] = true
+	}
+}
+
+func TestAllocateGivesUpWhenEverythingIsTaken(t *testing.T) {
+	asked := 0
+	_, err := Allocate(func(string) bool {
+		asked++
+		return true
+	})
+	if err == nil || !strings.Contains(err.Error(), "Failed to find a free account number") {
+		t.Fatalf("got %v, want an error about no free number", err)
+	}
+	if asked != maxAttempts {
+		t.Fatalf("tried %d numbers, want %d", asked, maxAttempts)
+	}
+}
@@ -0,0 +1,161 @@
+package authee
+
+import (
+	pb "assignment_2/proto"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// merchants send their api key in this header instead of a user token
+const apiKeyHeader = "x-api-key"
+
+// keys look like sk_<keyId>_<secret>, the key id is public and finds the stored hash
+const apiKeyPrefix = "sk_"
+
+// verified keys are trusted this long, so a revoked key stops working within this time
+const apiKeyCacheLifetime = 30 * time.Second
+
+// checks an api key and returns the merchant it belongs to
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*pb.MerchantDetails, error)
+}
+
+// nil means this service does not accept api keys
+var apiKeyVerifier APIKeyVerifier
+
+// function to accept merchant api keys on this service, call before serving
+func EnableAPIKeys(verifier APIKeyVerifier) {
+	apiKeyVerifier = verifier
+}
+
+// function to generate a new key, returns its id and the full key
+func GenerateAPIKey() (string, string, error) {
+	raw := make([]byte, 24)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to generate api key: %v", err)
+	}
+	encoded := hex.EncodeToString(raw)
+	keyId := encoded[:8]
+	return keyId, apiKeyPrefix + keyId + "_" + encoded[8:], nil
+}
+
+// function to split a key into its id and secret
+func ParseAPIKey(key string) (string, string, bool) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return "", "", false
+	}
+	keyId, secret, found := strings.Cut(strings.TrimPrefix(key, apiKeyPrefix), "_")
+	if !found || keyId == "" || secret == "" {
+		return "", "", false
+	}
+	return keyId, secret, true
+}
+
+// function to hash a key for storage, keys are random enough that plain sha256 does
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// function to check an api key through the verifier and turn it into a merchant caller
+func checkAPIKey(ctx context.Context, key string) (*Caller, error) {
+	if apiKeyVerifier == nil {
+		return nil, authError(codes.Unauthenticated, ReasonInvalidAPIKey, "API keys are not accepted here", nil)
+	}
+
+	merchant, err := apiKeyVerifier.VerifyAPIKey(ctx, key)
+	if status.Code(err) == codes.Unavailable {
+		return nil, status.Error(codes.Unavailable, "Can't verify API key right now")
+	}
+	if err != nil {
+		return nil, authError(codes.Unauthenticated, ReasonInvalidAPIKey, "Invalid API key", nil)
+	}
+
+	return &Caller{
+		Username:      merchant.MerchantId,
+		Role:          RoleMerchant,
+		AccountNumber: merchant.PayoutAccount,
+		MerchantId:    merchant.MerchantId,
+	}, nil
+}
+
+// one verified key kept by RemoteAPIKeyVerifier
+type cachedAPIKey struct {
+	merchant *pb.MerchantDetails
+	expiry   time.Time
+}
+
+// verifier asking the auth servers, results are cached for apiKeyCacheLifetime
+type RemoteAPIKeyVerifier struct {
+	authLoadBalancerAddress string
+	dialOptions             []grpc.DialOption
+	mu                      sync.Mutex
+	//hash of key : merchant, plain keys are never kept around
+	cache map[string]cachedAPIKey
+}
+
+// function to create a verifier, dial options must carry tls and this service's identity
+func NewRemoteAPIKeyVerifier(authLoadBalancerAddress string, dialOptions ...grpc.DialOption) *RemoteAPIKeyVerifier {
+	return &RemoteAPIKeyVerifier{
+		authLoadBalancerAddress: authLoadBalancerAddress,
+		dialOptions:             dialOptions,
+		cache:                   make(map[string]cachedAPIKey),
+	}
+}
+
+func (rv *RemoteAPIKeyVerifier) VerifyAPIKey(ctx context.Context, key string) (*pb.MerchantDetails, error) {
+	hash := HashAPIKey(key)
+
+	rv.mu.Lock()
+	cached, found := rv.cache[hash]
+	rv.mu.Unlock()
+	if found && time.Now().Before(cached.expiry) {
+		return cached.merchant, nil
+	}
+
+	merchant, err := rv.askAuthServer(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	rv.mu.Lock()
+	rv.cache[hash] = cachedAPIKey{merchant: merchant, expiry: time.Now().Add(apiKeyCacheLifetime)}
+	rv.mu.Unlock()
+	return merchant, nil
+}
+
+// function to verify the key on whichever auth server the load balancer hands out
+func (rv *RemoteAPIKeyVerifier) askAuthServer(ctx context.Context, key string) (*pb.MerchantDetails, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	lbConn, err := grpc.Dial(rv.authLoadBalancerAddress, rv.dialOptions...)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Failed to connect to auth load balancer: %v", err)
+	}
+	defer lbConn.Close()
+
+	server, err := pb.NewAuthLoadBalancerClient(lbConn).GetAuthServer(ctx, &pb.Empty{})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "No auth server available: %v", err)
+	}
+
+	authConn, err := grpc.Dial(server.Address, rv.dialOptions...)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Failed to connect to auth server: %v", err)
+	}
+	defer authConn.Close()
+
+	return pb.NewAuthenticationClient(authConn).VerifyAPIKey(ctx, &pb.APIKey{Key: key})
+}
@@ -0,0 +1,52 @@
+package authee
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// domain of every ErrorInfo detail we attach
+const errorDomain = "auth.secure-payment-gateway"
+
+// machine readable reasons, clients switch on these instead of the message
+const (
+	ReasonMissingToken        = "MISSING_TOKEN"
+	ReasonInvalidToken        = "INVALID_TOKEN"
+	ReasonInvalidServiceToken = "INVALID_SERVICE_TOKEN"
+	ReasonIdentityMismatch    = "IDENTITY_MISMATCH"
+	ReasonServiceNotAllowed   = "SERVICE_NOT_ALLOWED"
+	ReasonInternalOnly        = "INTERNAL_ONLY"
+	ReasonMissingScope        = "MISSING_SCOPE"
+	ReasonNotAccountOwner     = "NOT_ACCOUNT_OWNER"
+	ReasonInvalidAPIKey       = "INVALID_API_KEY"
+)
+
+// function to build a grpc status error with an ErrorInfo detail
+func authError(code codes.Code, reason, message string, meta map[string]string) error {
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: meta,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// function to get the reason of an auth failure, empty if err didn't come from us
+func ErrorReason(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if ok && info.Domain == errorDomain {
+			return info.Reason
+		}
+	}
+	return ""
+}
@@ -0,0 +1,45 @@
+package authee
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// methods which skip authentication entirely, health checks by default
+// deployments add more with AUTH_EXEMPT_METHODS (comma separated full method names)
+var exemptMethods = loadExemptMethods()
+var exemptMu sync.RWMutex
+
+func loadExemptMethods() map[string]bool {
+	exempt := map[string]bool{
+		"/grpc.health.v1.Health/Check": true,
+		"/grpc.health.v1.Health/Watch": true,
+	}
+
+	for _, method := range strings.Split(os.Getenv("AUTH_EXEMPT_METHODS"), ",") {
+		method = strings.TrimSpace(method)
+		if method != "" {
+			exempt[method] = true
+		}
+	}
+	return exempt
+}
+
+// function to exempt more methods, call before the server starts serving
+func ExemptMethods(methods ...string) {
+	exemptMu.Lock()
+	defer exemptMu.Unlock()
+
+	for _, method := range methods {
+		exemptMethods[method] = true
+	}
+}
+
+// function to check whether method skips authentication
+func IsExempt(fullMethod string) bool {
+	exemptMu.RLock()
+	defer exemptMu.RUnlock()
+
+	return exemptMethods[fullMethod]
+}
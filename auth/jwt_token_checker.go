@@ -7,14 +7,34 @@ import (
 
 	"github.com/golang-jwt/jwt/v4"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 )
 
 // secret key
 var jwtSecret = []byte("suyash")
 
+// authenticated caller kept in the request context
+// either Username and Role are set for users, or Service for our own services
+// merchants calling with an api key get MerchantId, Username is the merchant id then
+type Caller struct {
+	Username      string
+	Role          string
+	AccountNumber string
+	Service       string
+	MerchantId    string
+}
+
+type callerKey struct{}
+
+// function to get the authenticated caller of this request
+func CallerFromContext(ctx context.Context) (*Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(*Caller)
+	return caller, ok
+}
+
 // function to check and cross verify the jwt token
-func checkJWTToken(tokenString string) (string, error) {
+func checkJWTToken(tokenString string) (*Caller, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		_, isOk := token.Method.(*jwt.SigningMethodHMAC)
 		if !isOk {
@@ -24,7 +44,7 @@ func checkJWTToken(tokenString string) (string, error) {
 	})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	//now we have to extract claims from token
@@ -33,37 +53,121 @@ func checkJWTToken(tokenString string) (string, error) {
 	if isOk && token.Valid {
 		username, ok := claimss["username"].(string)
 		if !ok {
-			return "", fmt.Errorf("Invalid username field in token!")
+			return nil, fmt.Errorf("Invalid username field in token!")
 		}
-		return username, nil
+		//tokens issued before roles existed belong to customers
+		role, ok := claimss["role"].(string)
+		if !ok {
+			role = RoleCustomer
+		}
+		//admins created at bootstrap have no account
+		accountNumber, _ := claimss["accountNumber"].(string)
+		return &Caller{Username: username, Role: role, AccountNumber: accountNumber}, nil
 	}
 
-	return "", fmt.Errorf("Invalid Token sir!")
+	return nil, fmt.Errorf("Invalid Token sir!")
 }
 
-// enforce authentication
-func AuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+// function to authenticate and authorize one call, returns the context carrying the caller
+// shared by unary and stream interceptors
+func authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if publicMethods[fullMethod] || IsExempt(fullMethod) {
+		return ctx, nil
+	}
+
 	mdata, isOk := metadata.FromIncomingContext(ctx)
 	if !isOk {
-		return nil, fmt.Errorf("Missing Metadata!")
+		return nil, authError(codes.Unauthenticated, ReasonMissingToken, "Missing Metadata!", nil)
 	}
 
-	authHeader, exists := mdata["authorization"]
-	if !exists {
-		return nil, fmt.Errorf("Missing Authorization token")
+	//calls from our own services carry a service token instead of a user token
+	serviceHeader := mdata[serviceTokenHeader]
+	if len(serviceHeader) > 0 {
+		service, err := checkServiceToken(serviceHeader[0])
+		if err != nil {
+			return nil, authError(codes.Unauthenticated, ReasonInvalidServiceToken, "Invalid Service Token!", nil)
+		}
+		//with mutual tls the certificate must belong to the same service as the token
+		certIdentity := peerCertIdentity(ctx)
+		if certIdentity != "" && certIdentity != service {
+			fmt.Printf("Denied service token %s presented over certificate of %s\n", service, certIdentity)
+			return nil, authError(codes.PermissionDenied, ReasonIdentityMismatch, "Service token does not match client certificate",
+				map[string]string{"service": service, "certificate": certIdentity})
+		}
+		if !IsServiceAllowed(service, fullMethod) {
+			fmt.Printf("Denied service %s calling %s: not in allow-list\n", service, fullMethod)
+			return nil, authError(codes.PermissionDenied, ReasonServiceNotAllowed, fmt.Sprintf("Service %s may not call %s", service, fullMethod),
+				map[string]string{"service": service, "method": fullMethod})
+		}
+		return context.WithValue(ctx, callerKey{}, &Caller{Service: service}), nil
 	}
-	if len(authHeader) == 0 {
-		return nil, fmt.Errorf("Missing Authorization token")
+
+	if IsServiceOnly(fullMethod) {
+		return nil, authError(codes.PermissionDenied, ReasonInternalOnly, fmt.Sprintf("%s is only for internal services", fullMethod),
+			map[string]string{"method": fullMethod})
 	}
 
-	tokenStringHere := strings.TrimPrefix(authHeader[0], "Bearer ")
+	var caller *Caller
+	var err error
+
+	//merchants may use an api key instead of a user token
+	apiKey := mdata[apiKeyHeader]
+	if len(apiKey) > 0 {
+		caller, err = checkAPIKey(ctx, apiKey[0])
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		authHeader := mdata["authorization"]
+		if len(authHeader) == 0 {
+			return nil, authError(codes.Unauthenticated, ReasonMissingToken, "Missing Authorization token", nil)
+		}
 
-	username, err := checkJWTToken(tokenStringHere)
+		tokenStringHere := strings.TrimPrefix(authHeader[0], "Bearer ")
 
+		caller, err = checkJWTToken(tokenStringHere)
+
+		if err != nil {
+			return nil, authError(codes.Unauthenticated, ReasonInvalidToken, "Invalid Token", nil)
+		}
+	}
+
+	//now check role has the scope this rpc needs
+	scope := RequiredScope(fullMethod)
+	if scope != "" && !HasScope(caller.Role, scope) {
+		fmt.Printf("Denied %s (%s) calling %s: missing scope %s\n", caller.Username, caller.Role, fullMethod, scope)
+		return nil, authError(codes.PermissionDenied, ReasonMissingScope, fmt.Sprintf("%s requires scope %s", fullMethod, scope),
+			map[string]string{"method": fullMethod, "scope": scope, "role": caller.Role})
+	}
+
+	fmt.Println("Authenticated User:", caller.Username)
+	return context.WithValue(ctx, callerKey{}, caller), nil
+}
+
+// enforce authentication
+func AuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	authCtx, err := authenticate(ctx, info.FullMethod)
 	if err != nil {
-		return nil, fmt.Errorf("Invalid Token Sir G!")
+		return nil, err
 	}
+	return handler(authCtx, req)
+}
+
+// server stream carrying the authenticated caller in its context
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
 
-	fmt.Println("Authenticated User:", username)
-	return handler(ctx, req)
+func (as *authenticatedStream) Context() context.Context {
+	return as.ctx
+}
+
+// enforce authentication on streaming rpcs, same rules as the unary one
+func StreamAuthInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	authCtx, err := authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: authCtx})
 }
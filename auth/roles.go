@@ -0,0 +1,207 @@
+package authee
+
+import (
+	pb "assignment_2/proto"
+	"context"
+
+	"google.golang.org/grpc/codes"
+)
+
+// roles a user can hold, carried in the "role" claim of the jwt token
+const (
+	RoleCustomer = "customer"
+	RoleMerchant = "merchant"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+// scopes which guard individual rpcs
+const (
+	ScopePaymentsWrite = "payments:write"
+	ScopePaymentsRead  = "payments:read"
+	ScopeQueueAdmin    = "queue:admin"
+	ScopeBankDeposit   = "bank:deposit"
+	ScopeUsersAdmin    = "users:admin"
+	ScopeLogsWrite     = "logs:write"
+	//search the transaction log
+	ScopeLogsRead = "logs:read"
+	//freeze, unfreeze and act on accounts of other users
+	ScopeAccountsManage = "accounts:manage"
+	//credit accounts out of thin air, recorded in the ledger
+	ScopeAccountsFund = "accounts:fund"
+	//approve or reject payments held by the fraud rules
+	ScopeReviewsManage = "reviews:manage"
+	//create merchants and hand out or revoke their api keys
+	ScopeMerchantsManage = "merchants:manage"
+	//register webhook endpoints and replay failed deliveries
+	ScopeWebhooksManage = "webhooks:manage"
+)
+
+// what every role is allowed to do
+var roleScopes = map[string][]string{
+	RoleCustomer: {ScopePaymentsWrite, ScopePaymentsRead},
+	RoleMerchant: {ScopePaymentsWrite, ScopePaymentsRead, ScopeWebhooksManage},
+	RoleOperator: {ScopePaymentsRead, ScopeAccountsManage, ScopeAccountsFund, ScopeReviewsManage, ScopeLogsRead},
+	RoleAdmin:    {ScopePaymentsWrite, ScopePaymentsRead, ScopeQueueAdmin, ScopeBankDeposit, ScopeUsersAdmin, ScopeLogsWrite, ScopeLogsRead, ScopeAccountsManage, ScopeAccountsFund, ScopeReviewsManage, ScopeMerchantsManage, ScopeWebhooksManage},
+}
+
+// the one place where every rpc declares the scope it needs
+// rpcs not listed here only need a valid token
+var methodScopes = map[string]string{
+	pb.Authentication_AssignRole_FullMethodName:     ScopeUsersAdmin,
+	pb.Authentication_AssignTier_FullMethodName:     ScopeAccountsManage,
+	pb.Authentication_CreateMerchant_FullMethodName: ScopeMerchantsManage,
+	pb.Authentication_CreateAPIKey_FullMethodName:   ScopeMerchantsManage,
+	pb.Authentication_RevokeAPIKey_FullMethodName:   ScopeMerchantsManage,
+	pb.Authentication_ListAPIKeys_FullMethodName:    ScopeMerchantsManage,
+
+	pb.PaymentGateway_InitiateTransaction_FullMethodName:  ScopePaymentsWrite,
+	pb.PaymentGateway_ConfirmTransaction_FullMethodName:   ScopePaymentsWrite,
+	pb.PaymentGateway_GetTransactionStatus_FullMethodName: ScopePaymentsRead,
+
+	pb.ReviewService_ListPendingReviews_FullMethodName: ScopeReviewsManage,
+	pb.ReviewService_ApproveReview_FullMethodName:      ScopeReviewsManage,
+	pb.ReviewService_RejectReview_FullMethodName:       ScopeReviewsManage,
+
+	pb.OfflineQueueService_ProcessQueuedPayments_FullMethodName: ScopeQueueAdmin,
+	pb.OfflineQueueService_ListQueuedPayments_FullMethodName:    ScopeQueueAdmin,
+	pb.OfflineQueueService_GetQueuedPayment_FullMethodName:      ScopeQueueAdmin,
+	pb.OfflineQueueService_RequeuePayment_FullMethodName:        ScopeQueueAdmin,
+	pb.OfflineQueueService_DiscardPayment_FullMethodName:        ScopeQueueAdmin,
+
+	pb.WebhookService_RegisterWebhook_FullMethodName: ScopeWebhooksManage,
+	pb.WebhookService_ListWebhooks_FullMethodName:    ScopeWebhooksManage,
+	pb.WebhookService_DeleteWebhook_FullMethodName:   ScopeWebhooksManage,
+	pb.WebhookService_ListDeadLetters_FullMethodName: ScopeWebhooksManage,
+	pb.WebhookService_ReplayDelivery_FullMethodName:  ScopeWebhooksManage,
+
+	pb.BankServer_DepositMoney_FullMethodName: ScopeBankDeposit,
+
+	pb.BankServer_GetBalance_FullMethodName:            ScopePaymentsRead,
+	pb.BankServer_FreezeAccount_FullMethodName:         ScopeAccountsManage,
+	pb.BankServer_UnfreezeAccount_FullMethodName:       ScopeAccountsManage,
+	pb.BankServer_CloseAccount_FullMethodName:          ScopePaymentsWrite,
+	pb.BankServer_FundAccount_FullMethodName:           ScopeAccountsFund,
+	pb.BankLoadBalancer_GetAccountShard_FullMethodName: ScopePaymentsRead,
+
+	pb.LoggingService_LogTransaction_FullMethodName: ScopeLogsWrite,
+	pb.LoggingService_QueryLogs_FullMethodName:      ScopeLogsRead,
+	pb.LoggingService_VerifyLog_FullMethodName:      ScopeLogsRead,
+	pb.LoggingService_StreamLogs_FullMethodName:     ScopeLogsWrite,
+}
+
+// per service allow-list of which of our own services may call which rpcs
+// rpcs listed here but missing from methodScopes can't be called with a user token at all
+var serviceCallers = map[string][]string{
+	//auth load balancer
+	pb.AuthLoadBalancer_RegisterAuthServer_FullMethodName:   {ServiceAuthServer},
+	pb.AuthLoadBalancer_UpdateAuthServerLoad_FullMethodName: {ServiceAuthServer},
+
+	//authentication
+	pb.Authentication_VerifyAPIKey_FullMethodName: {ServicePaymentGateway, ServiceWebhooks},
+	pb.Authentication_GetMerchant_FullMethodName:  {ServicePaymentGateway},
+
+	//bank load balancer
+	pb.BankLoadBalancer_GetAllBankServers_FullMethodName:    {ServiceTwoPhaseCommit, ServiceAuthServer},
+	pb.BankLoadBalancer_RegisterBankServer_FullMethodName:   {ServiceBankServer},
+	pb.BankLoadBalancer_GetBankServer_FullMethodName:        {}, //no callers left, kept off limits for user tokens
+	pb.BankLoadBalancer_UpdateBankServerLoad_FullMethodName: {ServiceBankServer},
+	pb.BankLoadBalancer_AssignAccountShard_FullMethodName:   {ServiceAuthServer},
+	pb.BankLoadBalancer_GetAccountShard_FullMethodName:      {ServiceTwoPhaseCommit, ServicePaymentGateway},
+
+	//bank server
+	pb.BankServer_DeductMoney_FullMethodName:      {ServiceTwoPhaseCommit},
+	pb.BankServer_HasEnoughMoney_FullMethodName:   {ServiceTwoPhaseCommit},
+	pb.BankServer_RegisterUser_FullMethodName:     {ServiceAuthServer},
+	pb.BankServer_AbortTransaction_FullMethodName: {ServiceTwoPhaseCommit},
+	pb.BankServer_RevokeAccount_FullMethodName:    {ServiceAuthServer},
+	pb.BankServer_ListAccounts_FullMethodName:     {ServiceAuthServer},
+	pb.BankServer_GetBalance_FullMethodName:       {ServicePaymentGateway},
+	pb.BankServer_DepositMoney_FullMethodName:     {ServiceTwoPhaseCommit},
+	pb.BankServer_SetAccountTier_FullMethodName:   {ServiceAuthServer},
+	pb.BankServer_ReverseDeposit_FullMethodName:   {ServiceTwoPhaseCommit},
+
+	//two phase commit
+	pb.TwoPhaseCommit_ReadyToCommitTransaction_FullMethodName: {ServicePaymentGateway},
+	pb.TwoPhaseCommit_CommitTransaction_FullMethodName:        {ServicePaymentGateway},
+	pb.TwoPhaseCommit_AbortTransaction_FullMethodName:         {ServicePaymentGateway},
+
+	//transaction logger
+	pb.LoggingService_LogTransaction_FullMethodName: {ServiceTwoPhaseCommit, ServiceBankServer},
+	pb.LoggingService_StreamLogs_FullMethodName:     {ServiceTwoPhaseCommit, ServiceBankServer},
+
+	//payment gateway
+	pb.PaymentGateway_ReplayTransaction_FullMethodName:       {ServiceOfflineQueue},
+	pb.PaymentGateway_SettleQueuedTransaction_FullMethodName: {ServiceOfflineQueue},
+	pb.PaymentGateway_ProcessQueuedPayments_FullMethodName:   {}, //never implemented, the offline queue takes these itself
+
+	//offline queue
+	pb.OfflineQueueService_ProcessQueuedPayments_FullMethodName: {ServicePaymentGateway},
+}
+
+// rpcs anyone can call without a token
+var publicMethods = map[string]bool{
+	pb.Authentication_Register_FullMethodName:   true,
+	pb.Authentication_Login_FullMethodName:      true,
+	pb.Authentication_VerifyTOTP_FullMethodName: true,
+
+	//client asks for an auth server before it has any token
+	pb.AuthLoadBalancer_GetAuthServer_FullMethodName: true,
+}
+
+// function to check whether role is one we know about
+func IsValidRole(role string) bool {
+	_, exists := roleScopes[role]
+	return exists
+}
+
+// function to check whether role has been granted this scope
+func HasScope(role, scope string) bool {
+	for _, granted := range roleScopes[role] {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// function to check whether one of our services may call this rpc
+func IsServiceAllowed(service, fullMethod string) bool {
+	for _, allowed := range serviceCallers[fullMethod] {
+		if allowed == service {
+			return true
+		}
+	}
+	return false
+}
+
+// function to check whether rpc is reserved for our own services
+func IsServiceOnly(fullMethod string) bool {
+	_, internal := serviceCallers[fullMethod]
+	_, scoped := methodScopes[fullMethod]
+	return internal && !scoped
+}
+
+// function to get the scope an rpc needs, empty if it only needs a valid token
+func RequiredScope(fullMethod string) string {
+	return methodScopes[fullMethod]
+}
+
+// function to check the caller may act on this account
+// our own services and callers with accounts:manage may act on any account, users only on their own
+func AuthorizeAccount(ctx context.Context, accountNumber string) error {
+	caller, ok := CallerFromContext(ctx)
+	if !ok {
+		return authError(codes.Unauthenticated, ReasonMissingToken, "Missing caller", nil)
+	}
+
+	if caller.Service != "" || HasScope(caller.Role, ScopeAccountsManage) {
+		return nil
+	}
+
+	if caller.AccountNumber != accountNumber {
+		return authError(codes.PermissionDenied, ReasonNotAccountOwner, "Account does not belong to caller",
+			map[string]string{"account": accountNumber, "user": caller.Username})
+	}
+	return nil
+}
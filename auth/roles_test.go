@@ -0,0 +1,146 @@
+package authee
+
+import (
+	pb "assignment_2/proto"
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// function to sign a user token the way the auth server does
+func userToken(t *testing.T, username, role string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"username": username, "role": role})
+	signed, err := token.SignedString(jwtSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func incoming(pairs ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+// function to check err is a status error with this code and reason
+func expectDenied(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+	if status.Code(err) != code || ErrorReason(err) != reason {
+		t.Fatalf("got %v (reason %q), want %s with reason %s", err, ErrorReason(err), code, reason)
+	}
+}
+
+func TestRoleNeedsScopeOfRpc(t *testing.T) {
+	cases := []struct {
+		role    string
+		method  string
+		allowed bool
+	}{
+		{RoleCustomer, pb.PaymentGateway_InitiateTransaction_FullMethodName, true},
+		{RoleCustomer, pb.ReviewService_ApproveReview_FullMethodName, false},
+		{RoleCustomer, pb.Authentication_AssignRole_FullMethodName, false},
+		{RoleOperator, pb.ReviewService_ApproveReview_FullMethodName, true},
+		{RoleOperator, pb.PaymentGateway_InitiateTransaction_FullMethodName, false},
+		{RoleMerchant, pb.WebhookService_RegisterWebhook_FullMethodName, true},
+		{RoleMerchant, pb.Authentication_CreateMerchant_FullMethodName, false},
+		{RoleAdmin, pb.Authentication_AssignRole_FullMethodName, true},
+		{"stranger", pb.PaymentGateway_GetTransactionStatus_FullMethodName, false},
+	}
+
+	for _, c := range cases {
+		ctx := incoming("authorization", "Bearer "+userToken(t, "suyash", c.role))
+		authCtx, err := authenticate(ctx, c.method)
+		if !c.allowed {
+			if err == nil {
+				t.Errorf("%s calling %s was let through", c.role, c.method)
+				continue
+			}
+			if status.Code(err) != codes.PermissionDenied || ErrorReason(err) != ReasonMissingScope {
+				t.Errorf("%s calling %s: got %v, want %s", c.role, c.method, err, ReasonMissingScope)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s calling %s refused: %v", c.role, c.method, err)
+			continue
+		}
+		caller, ok := CallerFromContext(authCtx)
+		if !ok || caller.Username != "suyash" || caller.Role != c.role {
+			t.Errorf("%s calling %s: caller %+v in context", c.role, c.method, caller)
+		}
+	}
+}
+
+func TestTokenWithoutRoleIsCustomer(t *testing.T) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"username": "old"})
+	signed, _ := token.SignedString(jwtSecret)
+
+	if _, err := authenticate(incoming("authorization", "Bearer "+signed), pb.PaymentGateway_InitiateTransaction_FullMethodName); err != nil {
+		t.Fatalf("token from before roles refused: %v", err)
+	}
+	_, err := authenticate(incoming("authorization", "Bearer "+signed), pb.ReviewService_ApproveReview_FullMethodName)
+	expectDenied(t, err, codes.PermissionDenied, ReasonMissingScope)
+}
+
+func TestMissingOrForgedUserToken(t *testing.T) {
+	method := pb.PaymentGateway_InitiateTransaction_FullMethodName
+
+	_, err := authenticate(context.Background(), method)
+	expectDenied(t, err, codes.Unauthenticated, ReasonMissingToken)
+
+	_, err = authenticate(incoming("other", "header"), method)
+	expectDenied(t, err, codes.Unauthenticated, ReasonMissingToken)
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"username": "suyash", "role": RoleAdmin})
+	signed, _ := forged.SignedString([]byte("not the secret"))
+	_, err = authenticate(incoming("authorization", "Bearer "+signed), method)
+	expectDenied(t, err, codes.Unauthenticated, ReasonInvalidToken)
+}
+
+func TestPublicMethodsNeedNoToken(t *testing.T) {
+	for _, method := range []string{pb.Authentication_Login_FullMethodName, pb.Authentication_Register_FullMethodName, "/grpc.health.v1.Health/Check"} {
+		if _, err := authenticate(context.Background(), method); err != nil {
+			t.Errorf("%s refused without token: %v", method, err)
+		}
+	}
+}
+
+func TestServiceOnlyRpcRefusesUserToken(t *testing.T) {
+	if !IsServiceOnly(pb.BankServer_DeductMoney_FullMethodName) {
+		t.Fatal("DeductMoney should be service only")
+	}
+	//listed for services but also scoped for users
+	if IsServiceOnly(pb.BankServer_DepositMoney_FullMethodName) {
+		t.Fatal("DepositMoney should also take admin tokens")
+	}
+	if IsServiceOnly(pb.PaymentGateway_InitiateTransaction_FullMethodName) {
+		t.Fatal("InitiateTransaction is not service only")
+	}
+
+	//not even an admin gets through
+	ctx := incoming("authorization", "Bearer "+userToken(t, "root", RoleAdmin))
+	_, err := authenticate(ctx, pb.BankServer_DeductMoney_FullMethodName)
+	expectDenied(t, err, codes.PermissionDenied, ReasonInternalOnly)
+}
+
+func TestAuthorizeAccount(t *testing.T) {
+	owner := context.WithValue(context.Background(), callerKey{}, &Caller{Username: "a", Role: RoleCustomer, AccountNumber: "1234567897"})
+	if err := AuthorizeAccount(owner, "1234567897"); err != nil {
+		t.Fatalf("owner refused: %v", err)
+	}
+	expectDenied(t, AuthorizeAccount(owner, "0000000000"), codes.PermissionDenied, ReasonNotAccountOwner)
+
+	operator := context.WithValue(context.Background(), callerKey{}, &Caller{Username: "op", Role: RoleOperator})
+	if err := AuthorizeAccount(operator, "0000000000"); err != nil {
+		t.Fatalf("operator refused: %v", err)
+	}
+	service := context.WithValue(context.Background(), callerKey{}, &Caller{Service: ServiceTwoPhaseCommit})
+	if err := AuthorizeAccount(service, "0000000000"); err != nil {
+		t.Fatalf("service refused: %v", err)
+	}
+	expectDenied(t, AuthorizeAccount(context.Background(), "0000000000"), codes.Unauthenticated, ReasonMissingToken)
+}
@@ -0,0 +1,145 @@
+package authee
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// identities of our own services, carried in the "service" claim of a service token
+const (
+	ServiceAuthServer       = "auth-server"
+	ServiceAuthLoadBalancer = "auth-load-balancer"
+	ServiceBankServer       = "bank-server"
+	ServiceBankLoadBalancer = "bank-load-balancer"
+	ServicePaymentGateway   = "payment-gateway"
+	ServiceTwoPhaseCommit   = "two-phase-commit"
+	ServiceTransactionLog   = "transaction-logger"
+	ServiceOfflineQueue     = "offline-queue"
+	ServiceWebhooks         = "webhook-dispatcher"
+	ServiceRestGateway      = "rest-gateway"
+)
+
+// service tokens travel in their own header so they never get mixed with user tokens
+const serviceTokenHeader = "x-service-token"
+
+// how long one service token stays valid
+const serviceTokenLifetime = 5 * time.Minute
+
+// shortest SERVICE_TOKEN_SECRET accepted, hs256 wants a key of at least 256 bits
+const minServiceSecretLength = 32
+
+// separate secret from user tokens so a user can never pass as a service
+// set by LoadServiceSecret, there is no default anyone could look up
+var serviceSecret []byte
+
+// function to read SERVICE_TOKEN_SECRET, every service calls it first thing and refuses to start without it
+func LoadServiceSecret() error {
+	secret := os.Getenv("SERVICE_TOKEN_SECRET")
+	if len(secret) < minServiceSecretLength {
+		return fmt.Errorf("SERVICE_TOKEN_SECRET must be set to at least %d characters", minServiceSecretLength)
+	}
+	serviceSecret = []byte(secret)
+	return nil
+}
+
+// function to sign a short lived token for this service
+func generateServiceToken(service string) (string, time.Time, error) {
+	expiry := time.Now().Add(serviceTokenLifetime)
+	claim := jwt.MapClaims{
+		"service": service,
+		"iat":     time.Now().Unix(),
+		"exp":     expiry.Unix(),
+	}
+
+	if serviceSecret == nil {
+		return "", time.Time{}, fmt.Errorf("Service token secret not loaded")
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
+	signed, err := token.SignedString(serviceSecret)
+	return signed, expiry, err
+}
+
+// function to check the service token and return which service sent it
+func checkServiceToken(tokenString string) (string, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		_, isOk := token.Method.(*jwt.SigningMethodHMAC)
+		if !isOk {
+			return nil, fmt.Errorf("Unexpected Signing Method")
+		}
+		if serviceSecret == nil {
+			return nil, fmt.Errorf("Service token secret not loaded")
+		}
+		return serviceSecret, nil
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	claimss, isOk := token.Claims.(jwt.MapClaims)
+	if isOk && token.Valid {
+		service, ok := claimss["service"].(string)
+		if !ok || service == "" {
+			return "", fmt.Errorf("Invalid service field in token!")
+		}
+		return service, nil
+	}
+
+	return "", fmt.Errorf("Invalid Service Token!")
+}
+
+// function to get the identity from a verified client certificate, empty without mutual tls
+func peerCertIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+}
+
+// per rpc credentials attaching a service token to every outgoing call
+type ServiceCredentials struct {
+	service string
+	mu      sync.Mutex
+	token   string
+	expiry  time.Time
+}
+
+// function to create credentials for the calling service
+// use with grpc.WithPerRPCCredentials when dialing other services
+func NewServiceCredentials(service string) *ServiceCredentials {
+	return &ServiceCredentials{service: service}
+}
+
+func (sc *ServiceCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	//refresh a bit before expiry so a slow call never carries a dead token
+	if sc.token == "" || time.Until(sc.expiry) < time.Minute {
+		token, expiry, err := generateServiceToken(sc.service)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to generate service token: %v", err)
+		}
+		sc.token = token
+		sc.expiry = expiry
+	}
+
+	return map[string]string{serviceTokenHeader: sc.token}, nil
+}
+
+// service tokens are never sent over plaintext
+func (sc *ServiceCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,87 @@
+package authee
+
+import (
+	pb "assignment_2/proto"
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+// function to load a service secret for the length of one test
+func withServiceSecret(t *testing.T) {
+	t.Helper()
+	t.Setenv("SERVICE_TOKEN_SECRET", strings.Repeat("s", minServiceSecretLength))
+	if err := LoadServiceSecret(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { serviceSecret = nil })
+}
+
+func TestServiceAllowList(t *testing.T) {
+	withServiceSecret(t)
+
+	call := func(service, method string) (context.Context, error) {
+		token, _, err := generateServiceToken(service)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return authenticate(incoming(serviceTokenHeader, token), method)
+	}
+
+	authCtx, err := call(ServiceTwoPhaseCommit, pb.BankServer_DeductMoney_FullMethodName)
+	if err != nil {
+		t.Fatalf("2pc refused DeductMoney: %v", err)
+	}
+	caller, _ := CallerFromContext(authCtx)
+	if caller.Service != ServiceTwoPhaseCommit {
+		t.Fatalf("caller %+v in context, want service %s", caller, ServiceTwoPhaseCommit)
+	}
+
+	_, err = call(ServicePaymentGateway, pb.BankServer_DeductMoney_FullMethodName)
+	expectDenied(t, err, codes.PermissionDenied, ReasonServiceNotAllowed)
+
+	//nobody is on the list for this one
+	_, err = call(ServiceAuthServer, pb.BankLoadBalancer_GetBankServer_FullMethodName)
+	expectDenied(t, err, codes.PermissionDenied, ReasonServiceNotAllowed)
+
+	//rpc for users only, no service is listed
+	_, err = call(ServiceTwoPhaseCommit, pb.ReviewService_ApproveReview_FullMethodName)
+	expectDenied(t, err, codes.PermissionDenied, ReasonServiceNotAllowed)
+}
+
+func TestServiceTokenFromOtherSecret(t *testing.T) {
+	withServiceSecret(t)
+	token, _, err := generateServiceToken(ServiceTwoPhaseCommit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceSecret = []byte(strings.Repeat("x", minServiceSecretLength))
+	_, err = authenticate(incoming(serviceTokenHeader, token), pb.BankServer_DeductMoney_FullMethodName)
+	expectDenied(t, err, codes.Unauthenticated, ReasonInvalidServiceToken)
+
+	//a user token never passes as a service token
+	_, err = authenticate(incoming(serviceTokenHeader, userToken(t, "root", RoleAdmin)), pb.BankServer_DeductMoney_FullMethodName)
+	expectDenied(t, err, codes.Unauthenticated, ReasonInvalidServiceToken)
+}
+
+func TestServiceSecretMustBeLoaded(t *testing.T) {
+	serviceSecret = nil
+	if _, _, err := generateServiceToken(ServiceBankServer); err == nil {
+		t.Fatal("service token signed without a secret")
+	}
+
+	t.Setenv("SERVICE_TOKEN_SECRET", "")
+	if err := LoadServiceSecret(); err == nil {
+		t.Fatal("empty SERVICE_TOKEN_SECRET accepted")
+	}
+	t.Setenv("SERVICE_TOKEN_SECRET", strings.Repeat("s", minServiceSecretLength-1))
+	if err := LoadServiceSecret(); err == nil {
+		t.Fatal("short SERVICE_TOKEN_SECRET accepted")
+	}
+	if serviceSecret != nil {
+		t.Fatal("refused secret was kept")
+	}
+}
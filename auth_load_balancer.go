@@ -1,7 +1,9 @@
 package main
 
 import (
+	authee "assignment_2/auth"
 	pb "assignment_2/proto"
+	"assignment_2/tlsutil"
 	"context"
 	"fmt"
 	"log"
@@ -9,6 +11,8 @@ import (
 	"sync"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // auth server
@@ -73,12 +77,18 @@ func (lb *AuthLoadBalancer) UpdateAuthServerLoad(ctx context.Context, req *pb.Au
 }
 
 func main() {
+	//service tokens can't be signed or checked without the shared secret
+	if err := authee.LoadServiceSecret(); err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	listen, err := net.Listen("tcp", ":50055")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.Creds(tlsutil.ServerCredentials(authee.ServiceAuthLoadBalancer)), grpc.UnaryInterceptor(authee.AuthInterceptor), grpc.StreamInterceptor(authee.StreamAuthInterceptor))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
 	authLB := &AuthLoadBalancer{authServers: make(map[string]*AuthServerInfo)}
 	pb.RegisterAuthLoadBalancerServer(grpcServer, authLB)
 
@@ -1,12 +1,19 @@
 package main
 
 import (
+	"assignment_2/accountnum"
+	authee "assignment_2/auth"
 	pb "assignment_2/proto"
+	"assignment_2/tlsutil"
+	"assignment_2/totp"
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 	"log"
-	"math/rand"
 	"net"
+	"os"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -14,7 +21,12 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 type AuthServer struct {
@@ -26,10 +38,73 @@ type AuthServer struct {
 var users = make(map[string]*pb.ClientDetails)
 var mu sync.Mutex
 
+// every account number this server handed out, guarded by mu
+var allocatedAccounts = make(map[string]bool)
+
+// users reserved by a Register still opening their bank account, guarded by mu
+// the bank calls run without mu, these users can't log in and reconciliation leaves them alone
+var registering = make(map[string]bool)
+
+// how often we draw a new number when the bank says ours is already taken by another auth server
+const maxAccountNumberCollisions = 3
+
+// retries of one bank call while the shard is unreachable
+const maxBankAttempts = 3
+const bankRetryDelay = 200 * time.Millisecond
+
+// how often orphaned users and accounts are looked for
+const reconcileInterval = time.Minute
+
+var bankLoadBalancerAddress = "localhost:50056"
+
 var jwtSecretKey = []byte("suyash")
 
+// issuer shown in authenticator apps
+const totpIssuer = "Suyash Bank of India"
+
+const loginChallengeLifetime = 5 * time.Minute
+const maxChallengeAttempts = 5
+const recoveryCodeCount = 10
+
+// second factor of one user
+type totpState struct {
+	secret    string
+	confirmed bool
+	//last time step accepted, same code can't be used twice
+	lastStep int64
+	//sha256 of recovery codes not used yet
+	recoveryCodes map[string]bool
+}
+
+// login waiting for its totp code
+type loginChallenge struct {
+	username string
+	expiry   time.Time
+	attempts int
+}
+
+// both guarded by mu like users
+var totpStates = make(map[string]*totpState)
+var loginChallenges = make(map[string]*loginChallenge)
+
+// api key of a merchant, only the hash of the key is kept
+type apiKey struct {
+	merchantId string
+	hash       string
+	revoked    bool
+	createdAt  time.Time
+}
+
+// merchant id : merchant, key id : key, both guarded by mu like users
+var merchants = make(map[string]*pb.MerchantDetails)
+var apiKeys = make(map[string]*apiKey)
+
 var authLoadBalancerAddress = "localhost:50055"
 
+// every call to other services goes over tls and carries our service token
+var transportSecurity = grpc.WithTransportCredentials(tlsutil.ClientCredentials(authee.ServiceAuthServer))
+var serviceIdentity = grpc.WithPerRPCCredentials(authee.NewServiceCredentials(authee.ServiceAuthServer))
+
 func getCpuUsage() (float64, error) {
 	cmd := exec.Command("sh", "-c", "top -l 2 | grep 'CPU usage' | tail -n 1")
 	out, err := cmd.Output()
@@ -68,7 +143,7 @@ func (ser *AuthServer) updateCpuUsage() {
 			continue
 		}
 		//load balancer
-		conn, err := grpc.Dial(authLoadBalancerAddress, grpc.WithInsecure())
+		conn, err := grpc.Dial(authLoadBalancerAddress, transportSecurity, serviceIdentity)
 		if err != nil {
 			fmt.Println("Failed to connect to auth load balancer")
 			continue
@@ -91,7 +166,7 @@ func (ser *AuthServer) updateCpuUsage() {
 
 // first of all register with auth load balancer
 func registerWithAuthLoadBalancer(address string) {
-	conn, err := grpc.Dial(authLoadBalancerAddress, grpc.WithInsecure())
+	conn, err := grpc.Dial(authLoadBalancerAddress, transportSecurity, serviceIdentity)
 	if err != nil {
 		log.Fatalf("Failed to connect with auth load balancer")
 	}
@@ -107,17 +182,28 @@ func registerWithAuthLoadBalancer(address string) {
 	fmt.Println("Register with auth load balalncer successfully!")
 }
 
-// function to generate the 10 digit account number
-func generateAccountNumber() string {
-	return fmt.Sprintf("%010d", rand.Intn(1000000000))
+// function to generate the 10 digit account number, 9 random digits and a luhn check digit
+// never returns a number this server already handed out, caller holds mu
+func generateAccountNumber() (string, error) {
+	number, err := accountnum.Allocate(func(candidate string) bool {
+		return allocatedAccounts[candidate]
+	})
+	if err != nil {
+		return "", err
+	}
+
+	allocatedAccounts[number] = true
+	return number, nil
 }
 
 // function to generate the jwt token
-func generateJWTToken(username string) (string, error) {
+func generateJWTToken(username, role, accountNumber string) (string, error) {
 
 	//token claim
 	claim := jwt.MapClaims{
 		"username":        username,
+		"role":            role,
+		"accountNumber":   accountNumber,
 		"tokenExpireTime": time.Now().Add(time.Hour * 24).Unix(),
 		"tokenIssueTime":  time.Now().Unix(),
 	}
@@ -127,72 +213,279 @@ func generateJWTToken(username string) (string, error) {
 	return token.SignedString(jwtSecretKey)
 }
 
-// function to get an available bank server
-func getAvaialbleBankServer() (pb.BankServerClient, error) {
+// function to get the bank server owning this account, caller closes the returned conn
+func getOwningBankServer(accountNumber string) (pb.BankServerClient, *grpc.ClientConn, error) {
 	//first of all connect with bank load balancer
-	conn, err := grpc.Dial("localhost:50056", grpc.WithInsecure())
+	conn, err := grpc.Dial(bankLoadBalancerAddress, transportSecurity, serviceIdentity)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to connect with bank load balancer: %v", err)
+		return nil, nil, fmt.Errorf("Failed to connect with bank load balancer: %v", err)
 	}
 
 	defer conn.Close()
 
 	client := pb.NewBankLoadBalancerClient(conn)
-	bankServer, err := client.GetBankServer(context.Background(), &pb.Empty{})
+	bankServer, err := client.AssignAccountShard(context.Background(), &pb.AccountRequest{AccountNumber: accountNumber})
 	if err != nil {
-		return nil, fmt.Errorf("No available Bank Server")
+		return nil, nil, status.Errorf(codes.Unavailable, "No available Bank Server")
 	}
 
 	//now try to connect with bank server
-	bankConn, errf := grpc.Dial(bankServer.Address, grpc.WithInsecure())
+	bankConn, errf := grpc.Dial(bankServer.Address, transportSecurity, serviceIdentity)
 	if errf != nil {
-		return nil, fmt.Errorf("Failed to connect to selected Bank Server")
+		return nil, nil, status.Errorf(codes.Unavailable, "Failed to connect to selected Bank Server")
 	}
-	return pb.NewBankServerClient(bankConn), nil
+	return pb.NewBankServerClient(bankConn), bankConn, nil
+}
 
+// function to call RegisterUser a few times, the bank treats a repeated opening as a no-op
+// so a retry after a lost response never opens a second account
+func registerWithBank(ctx context.Context, bankClient pb.BankServerClient, req *pb.ClientDetails) error {
+	delay := bankRetryDelay
+	var err error
+	for attempt := 1; attempt <= maxBankAttempts; attempt++ {
+		_, err = bankClient.RegisterUser(ctx, req)
+		code := status.Code(err)
+		if code != codes.Unavailable && code != codes.DeadlineExceeded {
+			return err
+		}
+		fmt.Printf("Attempt No. %d to open account %s failed, waiting %v...\n", attempt, req.AccountNumber, delay)
+		time.Sleep(delay)
+		delay *= 2
+	}
+	return err
+}
+
+// saga step 2: open the account on its owning shard, returns the account number finally used
+// another auth server may have handed out the same number, then a new one is drawn
+// makes bank calls, so the caller must not hold mu
+func openBankAccount(ctx context.Context, bankReq *pb.ClientDetails) (string, error) {
+	for collisions := 0; ; collisions++ {
+		bankClient, bankConn, err := getOwningBankServer(bankReq.AccountNumber)
+		if err != nil {
+			return bankReq.AccountNumber, err
+		}
+
+		err = registerWithBank(ctx, bankClient, bankReq)
+		bankConn.Close()
+
+		if status.Code(err) != codes.AlreadyExists || collisions >= maxAccountNumberCollisions {
+			return bankReq.AccountNumber, err
+		}
+
+		mu.Lock()
+		bankReq.AccountNumber, err = generateAccountNumber()
+		mu.Unlock()
+		if err != nil {
+			return "", err
+		}
+		fmt.Println("Account number collision, retrying with: ", bankReq.AccountNumber)
+	}
+}
+
+// what the bank needs to open an account, never the password
+func bankDetails(user *pb.ClientDetails) *pb.ClientDetails {
+	return &pb.ClientDetails{
+		Username:      user.Username,
+		Email:         user.Email,
+		AccountNumber: user.AccountNumber,
+		Tier:          user.Tier,
+	}
+}
+
+// compensation of step 2: revoke whatever the bank may have opened before failing
+// the account number stays allocated so the reconciliation job can still find it if this fails too
+func revokeBankAccount(username, accountNumber string) error {
+	bankClient, bankConn, err := getOwningBankServer(accountNumber)
+	if err != nil {
+		return err
+	}
+	defer bankConn.Close()
+
+	_, err = bankClient.RevokeAccount(context.Background(), &pb.ClientDetails{Username: username, AccountNumber: accountNumber})
+	return err
 }
 
 // function to register the new user
+// runs as a saga: 1. reserve the user  2. open the account on its owning shard
+// if step 2 fails the account is revoked and the user dropped, so the client can simply retry
+// mu is only held to reserve and to commit, a slow bank doesn't hold up logins meanwhile
 func (ser *AuthServer) Register(ctx context.Context, req *pb.ClientDetails) (*pb.Response, error) {
 	mu.Lock()
-	defer mu.Unlock()
-
-	_, exists := users[req.Username]
+	existing, exists := users[req.Username]
 
 	if exists {
+		inProgress := registering[req.Username]
+		mu.Unlock()
+		if existing.Password != req.Password {
+			return &pb.Response{Status: "Username taken"}, status.Errorf(codes.AlreadyExists, "Username %s already taken", req.Username)
+		}
+		//same request retried while the first one still talks to the bank
+		if inProgress {
+			return &pb.Response{Status: "Registration in progress"}, status.Errorf(codes.Aborted, "Registration of %s is still in progress", req.Username)
+		}
+		//same request retried after a lost response
 		return &pb.Response{Status: "Already Registered User"}, nil
 	}
 
 	//auotmatically generates account number
-	accountNumber := generateAccountNumber()
+	accountNumber, err := generateAccountNumber()
+	if err != nil {
+		mu.Unlock()
+		return &pb.Response{Status: "Registration failed"}, err
+	}
 
-	users[req.Username] = &pb.ClientDetails{
+	//step 1: reserve the user
+	user := &pb.ClientDetails{
 		Username:      req.Username,
 		Password:      req.Password,
 		Email:         req.Email,
 		AccountNumber: accountNumber,
+		Role:          authee.RoleCustomer, //higher roles only through AssignRole
+		//tier and initial balance of the request are ignored, everyone starts on the default tier
+		//with its opening balance, operators change tiers with AssignTier and fund with FundAccount
+	}
+	users[req.Username] = user
+	registering[req.Username] = true
+	bankReq := bankDetails(user)
+	mu.Unlock()
+
+	//step 2: open the bank account
+	accountNumber, err = openBankAccount(ctx, bankReq)
+	if err != nil {
+		fmt.Println("Failed!: bank account creation failed, rolling back registration of ", req.Username)
+		if accountNumber != "" {
+			errRevoke := revokeBankAccount(req.Username, accountNumber)
+			if errRevoke != nil {
+				fmt.Printf("Failed to revoke account %s, reconciliation will pick it up: %v\n", accountNumber, errRevoke)
+			}
+		}
+		mu.Lock()
+		delete(users, req.Username)
+		delete(registering, req.Username)
+		mu.Unlock()
+		return &pb.Response{Status: "Registration failed, please retry"}, status.Errorf(codes.Unavailable, "Bank account creation failed: %v", err)
 	}
 
+	mu.Lock()
+	user.AccountNumber = accountNumber
+	delete(registering, req.Username)
+	mu.Unlock()
 	fmt.Println("User Registered with account number: ", accountNumber)
 
-	//now call to get bank server possibly
-	bankClient, err := getAvaialbleBankServer()
+	return &pb.Response{Status: "User registered successfully!"}, nil
+}
+
+// function to get every account across all bank shards with its owner
+// fails if any shard can't be read, reconciling on a partial view would "repair" healthy users
+func listAllBankAccounts() (map[string]string, error) {
+	conn, err := grpc.Dial(bankLoadBalancerAddress, transportSecurity, serviceIdentity)
 	if err != nil {
-		return &pb.Response{Status: "User registered but no available bank server currently"}, nil
+		return nil, fmt.Errorf("Failed to connect with bank load balancer: %v", err)
 	}
-	//register this user in bank server now:
-	bankReq := &pb.ClientDetails{
-		Username:      req.Username,
-		AccountNumber: accountNumber,
+	defer conn.Close()
+
+	servers, err := pb.NewBankLoadBalancerClient(conn).GetAllBankServers(context.Background(), &pb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list bank servers: %v", err)
+	}
+
+	accounts := make(map[string]string)
+	for _, server := range servers.Servers {
+		bankConn, err := grpc.Dial(server.Address, transportSecurity, serviceIdentity)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to connect to bank server %s: %v", server.Address, err)
+		}
+
+		list, err := pb.NewBankServerClient(bankConn).ListAccounts(context.Background(), &pb.Empty{})
+		bankConn.Close()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list accounts of %s: %v", server.Address, err)
+		}
+
+		for _, account := range list.Accounts {
+			accounts[account.AccountNumber] = account.Username
+		}
 	}
+	return accounts, nil
+}
 
-	_, ersr := bankClient.RegisterUser(ctx, bankReq)
-	if ersr != nil {
-		fmt.Println("Failed!: User registered but bank account creation failed!")
-		return &pb.Response{Status: "User registered, but bank account failed!"}, nil
+// an account to revoke found by reconciliation
+type orphanAccount struct {
+	accountNumber string
+	owner         string
+}
+
+// function to repair what a crashed or half compensated registration left behind
+//   - users whose account is missing on every shard get it opened again
+//   - accounts this server allocated but no user holds anymore get revoked
+//
+// the work is picked under mu and done without it, users still registering are skipped
+func reconcileAccounts() {
+	accounts, err := listAllBankAccounts()
+	if err != nil {
+		fmt.Println("Skipping reconciliation: ", err)
+		return
 	}
 
-	return &pb.Response{Status: "User registered successfully!"}, nil
+	mu.Lock()
+	held := make(map[string]bool)
+	var missing []*pb.ClientDetails
+	for _, user := range users {
+		if user.AccountNumber == "" {
+			continue
+		}
+		held[user.AccountNumber] = true
+
+		_, opened := accounts[user.AccountNumber]
+		if opened || registering[user.Username] {
+			continue
+		}
+		//a copy, the bank calls below run without mu
+		missing = append(missing, bankDetails(user))
+	}
+
+	var orphans []orphanAccount
+	for accountNumber, owner := range accounts {
+		if !allocatedAccounts[accountNumber] || held[accountNumber] || registering[owner] {
+			continue
+		}
+		orphans = append(orphans, orphanAccount{accountNumber: accountNumber, owner: owner})
+	}
+	mu.Unlock()
+
+	for _, details := range missing {
+		fmt.Printf("Reconcile: user %s has no bank account %s, opening it\n", details.Username, details.AccountNumber)
+		previous := details.AccountNumber
+		//reopened accounts get the opening balance of the user's tier again
+		accountNumber, err := openBankAccount(context.Background(), details)
+		if err != nil {
+			fmt.Printf("Reconcile: failed to open account for %s: %v\n", details.Username, err)
+			continue
+		}
+
+		mu.Lock()
+		user, exists := users[details.Username]
+		if exists && user.AccountNumber == previous {
+			user.AccountNumber = accountNumber
+		}
+		mu.Unlock()
+	}
+
+	for _, orphan := range orphans {
+		fmt.Printf("Reconcile: account %s of %s has no user, revoking it\n", orphan.accountNumber, orphan.owner)
+		err := revokeBankAccount(orphan.owner, orphan.accountNumber)
+		if err != nil {
+			fmt.Printf("Reconcile: failed to revoke account %s: %v\n", orphan.accountNumber, err)
+		}
+	}
+}
+
+// runs the reconciliation job forever
+func runReconciliation() {
+	for {
+		time.Sleep(reconcileInterval)
+		reconcileAccounts()
+	}
 }
 
 // function to login handle the request of user
@@ -211,9 +504,28 @@ func (ser *AuthServer) Login(ctx context.Context, req *pb.Credentials) (*pb.Auth
 		log.Printf("Error! Login attempt with invalid password for user: %s at %s", req.Username, time.Now().Format(time.RFC3339))
 		return nil, fmt.Errorf("Invalid Password!")
 	}
+	//the bank account may not be open yet
+	if registering[req.Username] {
+		return nil, status.Errorf(codes.Unavailable, "Registration of %s is still in progress", req.Username)
+	}
+
+	//users with a second factor get a challenge now and the token from VerifyTOTP
+	state, hasTOTP := totpStates[req.Username]
+	if hasTOTP && state.confirmed {
+		dropExpiredChallenges()
+
+		challengeId := uuid.New().String()
+		loginChallenges[challengeId] = &loginChallenge{
+			username: req.Username,
+			expiry:   time.Now().Add(loginChallengeLifetime),
+		}
+
+		log.Printf("User %s passed password check, waiting for totp code at %s", req.Username, time.Now().Format(time.RFC3339))
+		return &pb.AuthToken{MfaRequired: true, ChallengeId: challengeId}, nil
+	}
 
 	//generate the token sir
-	token, err := generateJWTToken(req.Username)
+	token, err := generateJWTToken(req.Username, user.Role, user.AccountNumber)
 
 	if err != nil {
 		return &pb.AuthToken{}, fmt.Errorf("Failed to generate token: %v", err)
@@ -225,7 +537,354 @@ func (ser *AuthServer) Login(ctx context.Context, req *pb.Credentials) (*pb.Auth
 
 }
 
+// function to forget login challenges nobody finished in time
+func dropExpiredChallenges() {
+	for id, challenge := range loginChallenges {
+		if time.Now().After(challenge.expiry) {
+			delete(loginChallenges, id)
+		}
+	}
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
+
+// function to start totp enrollment of the logged in user, it only becomes active after ConfirmTOTP
+func (ser *AuthServer) EnrollTOTP(ctx context.Context, req *pb.Empty) (*pb.TOTPEnrollment, error) {
+	caller, ok := authee.CallerFromContext(ctx)
+	if !ok || caller.Username == "" {
+		return nil, status.Error(codes.FailedPrecondition, "Only users can enroll totp")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	state, exists := totpStates[caller.Username]
+	if exists && state.confirmed {
+		return nil, status.Errorf(codes.FailedPrecondition, "Totp already enrolled for %s", caller.Username)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	//enrolling again before confirming just replaces the secret
+	totpStates[caller.Username] = &totpState{secret: secret}
+
+	log.Printf("User %s started totp enrollment at %s", caller.Username, time.Now().Format(time.RFC3339))
+	return &pb.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningUri: totp.ProvisioningURI(totpIssuer, caller.Username, secret),
+	}, nil
+}
+
+// function to activate totp with a first code from the app, hands out the recovery codes once
+func (ser *AuthServer) ConfirmTOTP(ctx context.Context, req *pb.TOTPCode) (*pb.RecoveryCodes, error) {
+	caller, ok := authee.CallerFromContext(ctx)
+	if !ok || caller.Username == "" {
+		return nil, status.Error(codes.FailedPrecondition, "Only users can confirm totp")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	state, exists := totpStates[caller.Username]
+	if !exists {
+		return nil, status.Error(codes.FailedPrecondition, "Totp enrollment not started")
+	}
+	if state.confirmed {
+		return nil, status.Errorf(codes.FailedPrecondition, "Totp already enrolled for %s", caller.Username)
+	}
+
+	step, valid := totp.Validate(state.secret, req.Code, time.Now(), state.lastStep)
+	if !valid {
+		return nil, status.Error(codes.InvalidArgument, "Invalid totp code")
+	}
+
+	recovery, err := totp.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	state.recoveryCodes = make(map[string]bool)
+	for _, code := range recovery {
+		state.recoveryCodes[hashRecoveryCode(code)] = true
+	}
+	state.confirmed = true
+	state.lastStep = step
+
+	log.Printf("User %s enabled totp at %s", caller.Username, time.Now().Format(time.RFC3339))
+	return &pb.RecoveryCodes{Codes: recovery}, nil
+}
+
+// second step of login, trades the challenge and a totp or recovery code for the token
+func (ser *AuthServer) VerifyTOTP(ctx context.Context, req *pb.TOTPVerification) (*pb.AuthToken, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	challenge, exists := loginChallenges[req.ChallengeId]
+	if !exists {
+		return nil, status.Error(codes.Unauthenticated, "Invalid or expired login challenge")
+	}
+	if time.Now().After(challenge.expiry) {
+		delete(loginChallenges, req.ChallengeId)
+		return nil, status.Error(codes.Unauthenticated, "Invalid or expired login challenge")
+	}
+
+	challenge.attempts++
+	if challenge.attempts > maxChallengeAttempts {
+		delete(loginChallenges, req.ChallengeId)
+		log.Printf("Error! Too many totp attempts for user: %s at %s", challenge.username, time.Now().Format(time.RFC3339))
+		return nil, status.Error(codes.ResourceExhausted, "Too many attempts, login again")
+	}
+
+	user, userExists := users[challenge.username]
+	state, stateExists := totpStates[challenge.username]
+	if !userExists || !stateExists {
+		delete(loginChallenges, req.ChallengeId)
+		return nil, status.Error(codes.Unauthenticated, "Invalid or expired login challenge")
+	}
+
+	step, valid := totp.Validate(state.secret, req.Code, time.Now(), state.lastStep)
+	if valid {
+		state.lastStep = step
+	} else {
+		//recovery codes work only once
+		hashed := hashRecoveryCode(req.Code)
+		if !state.recoveryCodes[hashed] {
+			log.Printf("Error! Invalid totp code for user: %s at %s", challenge.username, time.Now().Format(time.RFC3339))
+			return nil, status.Error(codes.Unauthenticated, "Invalid totp code")
+		}
+		delete(state.recoveryCodes, hashed)
+		log.Printf("User %s used a recovery code, %d left", challenge.username, len(state.recoveryCodes))
+	}
+
+	delete(loginChallenges, req.ChallengeId)
+
+	token, err := generateJWTToken(user.Username, user.Role, user.AccountNumber)
+	if err != nil {
+		return &pb.AuthToken{}, status.Errorf(codes.Internal, "Failed to generate token: %v", err)
+	}
+
+	log.Printf("User %s logged in successfully with totp at %s", user.Username, time.Now().Format(time.RFC3339))
+	return &pb.AuthToken{Token: token}, nil
+}
+
+// function to change role of an existing user, only admins reach here
+func (ser *AuthServer) AssignRole(ctx context.Context, req *pb.RoleAssignment) (*pb.Response, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !authee.IsValidRole(req.Role) {
+		return &pb.Response{Status: "Invalid Role"}, fmt.Errorf("Unknown role: %s", req.Role)
+	}
+
+	user, exists := users[req.Username]
+	if !exists {
+		return &pb.Response{Status: "User Not Found"}, fmt.Errorf("Invalid Username!")
+	}
+
+	user.Role = req.Role
+
+	assignedBy := "unknown"
+	caller, ok := authee.CallerFromContext(ctx)
+	if ok {
+		assignedBy = caller.Username
+	}
+	log.Printf("Role of user %s changed to %s by %s at %s", req.Username, req.Role, assignedBy, time.Now().Format(time.RFC3339))
+
+	return &pb.Response{Status: "Role assigned successfully!"}, nil
+}
+
+// function to move a user to another product tier, the bank account is changed first
+// the owning bank server rejects tiers it has no policy for
+func (ser *AuthServer) AssignTier(ctx context.Context, req *pb.TierAssignment) (*pb.Response, error) {
+	mu.Lock()
+	user, exists := users[req.Username]
+	if !exists {
+		mu.Unlock()
+		return &pb.Response{Status: "User Not Found"}, status.Errorf(codes.NotFound, "User %s not found", req.Username)
+	}
+	if registering[req.Username] {
+		mu.Unlock()
+		return &pb.Response{Status: "Registration in progress"}, status.Errorf(codes.FailedPrecondition, "Registration of %s is still in progress", req.Username)
+	}
+	accountNumber := user.AccountNumber
+	mu.Unlock()
+
+	bankClient, bankConn, err := getOwningBankServer(accountNumber)
+	if err != nil {
+		return &pb.Response{Status: "Tier not changed"}, err
+	}
+	defer bankConn.Close()
+
+	_, err = bankClient.SetAccountTier(ctx, &pb.TierAssignment{Username: req.Username, Tier: req.Tier, AccountNumber: accountNumber})
+	if err != nil {
+		return &pb.Response{Status: "Tier not changed"}, err
+	}
+
+	mu.Lock()
+	user.Tier = req.Tier
+	mu.Unlock()
+
+	assignedBy := "unknown"
+	caller, ok := authee.CallerFromContext(ctx)
+	if ok {
+		assignedBy = caller.Username
+	}
+	log.Printf("Tier of user %s changed to %s by %s at %s", req.Username, req.Tier, assignedBy, time.Now().Format(time.RFC3339))
+
+	return &pb.Response{Status: "Tier assigned successfully!"}, nil
+}
+
+// function to create a merchant which gets its payments on payoutAccount
+func (ser *AuthServer) CreateMerchant(ctx context.Context, req *pb.MerchantDetails) (*pb.MerchantDetails, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "Merchant name is required")
+	}
+	if err := accountnum.Validate(req.PayoutAccount); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Payout account: %v", err)
+	}
+	if !allocatedAccounts[req.PayoutAccount] {
+		return nil, status.Errorf(codes.FailedPrecondition, "Payout account %s is not known here", req.PayoutAccount)
+	}
+
+	merchant := &pb.MerchantDetails{
+		MerchantId:    "m_" + strings.ReplaceAll(uuid.New().String(), "-", "")[:12],
+		Name:          req.Name,
+		PayoutAccount: req.PayoutAccount,
+	}
+	merchants[merchant.MerchantId] = merchant
+
+	log.Printf("Merchant %s (%s) created with payout account %s", merchant.MerchantId, merchant.Name, merchant.PayoutAccount)
+	return merchant, nil
+}
+
+// function to issue a new api key, the full key is returned only this once
+func (ser *AuthServer) CreateAPIKey(ctx context.Context, req *pb.MerchantId) (*pb.APIKey, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	_, exists := merchants[req.MerchantId]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "Merchant %s not found", req.MerchantId)
+	}
+
+	keyId, key, err := authee.GenerateAPIKey()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	createdAt := time.Now()
+	apiKeys[keyId] = &apiKey{merchantId: req.MerchantId, hash: authee.HashAPIKey(key), createdAt: createdAt}
+
+	log.Printf("API key %s issued for merchant %s", keyId, req.MerchantId)
+	return &pb.APIKey{KeyId: keyId, Key: key, MerchantId: req.MerchantId, CreatedAt: createdAt.Format(time.RFC3339)}, nil
+}
+
+// function to revoke a key for good, services may accept it until their cache runs out
+func (ser *AuthServer) RevokeAPIKey(ctx context.Context, req *pb.APIKey) (*pb.Response, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	key, exists := apiKeys[req.KeyId]
+	if !exists {
+		return &pb.Response{Status: "API Key Not Found"}, status.Errorf(codes.NotFound, "API key %s not found", req.KeyId)
+	}
+	key.revoked = true
+
+	log.Printf("API key %s of merchant %s revoked", req.KeyId, key.merchantId)
+	return &pb.Response{Status: "API key revoked"}, nil
+}
+
+// function to list keys of a merchant without their secrets
+func (ser *AuthServer) ListAPIKeys(ctx context.Context, req *pb.MerchantId) (*pb.APIKeyList, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	list := &pb.APIKeyList{}
+	for keyId, key := range apiKeys {
+		if key.merchantId != req.MerchantId {
+			continue
+		}
+		list.Keys = append(list.Keys, &pb.APIKey{
+			KeyId:      keyId,
+			MerchantId: key.merchantId,
+			Revoked:    key.revoked,
+			CreatedAt:  key.createdAt.Format(time.RFC3339),
+		})
+	}
+	return list, nil
+}
+
+// function for other services to find the merchant behind an api key
+func (ser *AuthServer) VerifyAPIKey(ctx context.Context, req *pb.APIKey) (*pb.MerchantDetails, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	keyId, _, ok := authee.ParseAPIKey(req.Key)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Malformed API key")
+	}
+
+	key, exists := apiKeys[keyId]
+	if !exists || key.revoked {
+		return nil, status.Error(codes.Unauthenticated, "Unknown or revoked API key")
+	}
+	if subtle.ConstantTimeCompare([]byte(key.hash), []byte(authee.HashAPIKey(req.Key))) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "Invalid API key")
+	}
+
+	merchant, exists := merchants[key.merchantId]
+	if !exists {
+		return nil, status.Error(codes.Unauthenticated, "Merchant of API key is gone")
+	}
+	return merchant, nil
+}
+
+// function for the gateway to find the merchant a customer is paying
+func (ser *AuthServer) GetMerchant(ctx context.Context, req *pb.MerchantId) (*pb.MerchantDetails, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	merchant, exists := merchants[req.MerchantId]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "Merchant %s not found", req.MerchantId)
+	}
+	return merchant, nil
+}
+
+// function to create the first admin from env, nobody else can hand out the admin role
+func bootstrapAdmin() {
+	username := os.Getenv("BOOTSTRAP_ADMIN_USER")
+	password := os.Getenv("BOOTSTRAP_ADMIN_PASSWORD")
+	if username == "" || password == "" {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	users[username] = &pb.ClientDetails{
+		Username: username,
+		Password: password,
+		Role:     authee.RoleAdmin,
+	}
+	fmt.Println("Bootstrapped admin user: ", username)
+}
+
 func main() {
+	//service tokens can't be signed or checked without the shared secret
+	if err := authee.LoadServiceSecret(); err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	listen, err := net.Listen("tcp", ":0")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
@@ -237,11 +896,16 @@ func main() {
 
 	registerWithAuthLoadBalancer(addressHere)
 
-	server := grpc.NewServer()
+	bootstrapAdmin()
+
+	//register and login stay public, everything else needs a token
+	server := grpc.NewServer(grpc.Creds(tlsutil.ServerCredentials(authee.ServiceAuthServer)), grpc.UnaryInterceptor(authee.AuthInterceptor), grpc.StreamInterceptor(authee.StreamAuthInterceptor))
+	healthpb.RegisterHealthServer(server, health.NewServer())
 	authServer := &AuthServer{bankLoadBalancerAddress: "localhost:50055", address: addressHere}
 	pb.RegisterAuthenticationServer(server, authServer)
 
 	go authServer.updateCpuUsage()
+	go runReconciliation()
 
 	fmt.Println("gRPC Server is running on port ", port)
 	errr := server.Serve(listen)
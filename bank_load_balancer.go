@@ -1,7 +1,9 @@
 package main
 
 import (
+	authee "assignment_2/auth"
 	pb "assignment_2/proto"
+	"assignment_2/tlsutil"
 	"context"
 	"fmt"
 	"log"
@@ -9,6 +11,10 @@ import (
 	"sync"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 // auth server
@@ -21,7 +27,9 @@ type BankLoadBalancer struct {
 	pb.UnimplementedBankLoadBalancerServer
 	//list of all available auth servers maintaining
 	bankServers []BankServerInfo
-	muty        sync.Mutex
+	//account number : address of the bank server owning it
+	accountShards map[string]string
+	muty          sync.Mutex
 }
 
 // function to return all available bank servers
@@ -90,13 +98,64 @@ func (lb *BankLoadBalancer) GetBankServer(ctx context.Context, req *pb.Empty) (*
 	return &pb.ServerInfo{Address: lb.bankServers[mini].Address}, nil
 }
 
+// function to get the owning bank server of an account, first call picks the least loaded one
+// later calls always return the same server so retries land on the same shard
+func (lb *BankLoadBalancer) AssignAccountShard(ctx context.Context, req *pb.AccountRequest) (*pb.ServerInfo, error) {
+	lb.muty.Lock()
+	defer lb.muty.Unlock()
+
+	if lb.accountShards == nil {
+		lb.accountShards = make(map[string]string)
+	}
+
+	address, assigned := lb.accountShards[req.AccountNumber]
+	if assigned {
+		return &pb.ServerInfo{Address: address}, nil
+	}
+
+	if len(lb.bankServers) == 0 {
+		return nil, fmt.Errorf("No Bank Server Available")
+	}
+
+	mini := 0
+	for ind, server := range lb.bankServers {
+		if server.Load < lb.bankServers[mini].Load {
+			mini = ind
+		}
+	}
+
+	lb.bankServers[mini].Load++
+	lb.accountShards[req.AccountNumber] = lb.bankServers[mini].Address
+
+	fmt.Printf("Account %s assigned to bank server %s\n", req.AccountNumber, lb.bankServers[mini].Address)
+	return &pb.ServerInfo{Address: lb.bankServers[mini].Address}, nil
+}
+
+// function to look up the owning bank server of an account without assigning one
+func (lb *BankLoadBalancer) GetAccountShard(ctx context.Context, req *pb.AccountRequest) (*pb.ServerInfo, error) {
+	lb.muty.Lock()
+	defer lb.muty.Unlock()
+
+	address, assigned := lb.accountShards[req.AccountNumber]
+	if !assigned {
+		return nil, status.Errorf(codes.NotFound, "No bank server owns account %s", req.AccountNumber)
+	}
+	return &pb.ServerInfo{Address: address}, nil
+}
+
 func main() {
+	//service tokens can't be signed or checked without the shared secret
+	if err := authee.LoadServiceSecret(); err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	listen, err := net.Listen("tcp", ":50056")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.Creds(tlsutil.ServerCredentials(authee.ServiceBankLoadBalancer)), grpc.UnaryInterceptor(authee.AuthInterceptor), grpc.StreamInterceptor(authee.StreamAuthInterceptor))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
 	bankLB := &BankLoadBalancer{}
 
 	//register with the bank load balancer
@@ -1,18 +1,33 @@
 package main
 
 import (
+	"assignment_2/accountnum"
+	authee "assignment_2/auth"
+	"assignment_2/eventbus"
+	"assignment_2/limits"
+	"assignment_2/logship"
 	pb "assignment_2/proto"
+	"assignment_2/tlsutil"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net"
+	"os"
 	"os/exec"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 // bank server
@@ -21,11 +36,196 @@ type BankServer struct {
 	address string
 }
 
-// bank accounts : account number and balances
-var bankAccounts = make(map[string]float64)
+// lifecycle of an account, money only leaves active accounts
+const (
+	accountActive = "active"
+	accountFrozen = "frozen"
+	accountClosed = "closed"
+)
+
+// one account held by this bank server
+type bankAccount struct {
+	//username who owns it, tells a retried registration apart from a collision
+	owner   string
+	balance float64
+	status  string
+	tier    string
+	//money moved since opening, such an account can't be revoked anymore
+	touched bool
+}
+
+// bank accounts : account number and its details
+var bankAccounts = make(map[string]*bankAccount)
 var mute sync.Mutex
+
+// debits and credits already applied, by transaction id, so a retried 2PC step never moves money twice
+// guarded by mute, lives as long as the balances do
+var appliedTransfers = make(map[string]bool)
+
+func debitKey(transactionId string) string {
+	return "debit/" + transactionId
+}
+
+func creditKey(transactionId, accountNumber string) string {
+	return "credit/" + transactionId + "/" + accountNumber
+}
+
+func reversalKey(transactionId, accountNumber string) string {
+	return "reversal/" + transactionId + "/" + accountNumber
+}
+
 var bankLoadBalancerAddress = "localhost:50056"
 
+// every call to other services goes over tls and carries our service token
+var transportSecurity = grpc.WithTransportCredentials(tlsutil.ClientCredentials(authee.ServiceBankServer))
+var serviceIdentity = grpc.WithPerRPCCredentials(authee.NewServiceCredentials(authee.ServiceBankServer))
+
+var transactionLoggerAddress = "localhost:50058"
+
+// every ledger entry goes through a local spool, so none is lost while the logger is down
+var logShipper *logship.Shipper
+
+// every debit and credit of a payment goes out here as an event
+var eventBus eventbus.Bus
+
+// tier used when an account has none or an unknown one
+const defaultTier = "default"
+
+// opening balance rules of one product tier
+// nothing the registering user sends adds to it, operators top accounts up with FundAccount
+type openingPolicy struct {
+	//credited to every new account of this tier
+	OpeningBalance float64 `json:"openingBalance"`
+}
+
+// tier : opening policy, overridden per deployment by BANK_OPENING_POLICY_FILE
+// default keeps the 100 rupees gift to open account with Suyash Bank of India
+var openingPolicies = map[string]openingPolicy{
+	defaultTier: {OpeningBalance: 100},
+}
+
+// reason codes allowed for FundAccount, each lands in the ledger
+var fundingReasons = map[string]bool{
+	"TEST_SEED":  true,
+	"PROMOTION":  true,
+	"CORRECTION": true,
+	"REFUND":     true,
+}
+
+// spending limits checked before money leaves an account, unlimited unless BANK_LIMITS_FILE is set
+var spendingLimits = limits.NewEngine(limits.Config{})
+
+// function to load opening policies from the json file named in env, e.g.
+//
+//	{"default": {"openingBalance": 0}, "premium": {"openingBalance": 500}}
+func loadOpeningPolicies() {
+	path := os.Getenv("BANK_OPENING_POLICY_FILE")
+	if path == "" {
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("Failed to read opening policy file: %v", err)
+	}
+
+	policies := make(map[string]openingPolicy)
+	err = json.Unmarshal(data, &policies)
+	if err != nil {
+		log.Fatalf("Failed to parse opening policy file: %v", err)
+	}
+
+	_, hasDefault := policies[defaultTier]
+	if !hasDefault {
+		log.Fatalf("Opening policy file must contain a %q tier", defaultTier)
+	}
+
+	openingPolicies = policies
+	fmt.Printf("Loaded opening policies for %d tiers\n", len(policies))
+}
+
+// function to load per tier and per account spending limits from the json file named in env
+// debits counted against them are kept in BANK_LIMITS_HISTORY_FILE, named after the port by default
+func loadSpendingLimits(port int) {
+	path := os.Getenv("BANK_LIMITS_FILE")
+	if path == "" {
+		return
+	}
+
+	config, err := limits.LoadConfig(path)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	engine := limits.NewEngine(config)
+	historyPath := os.Getenv("BANK_LIMITS_HISTORY_FILE")
+	if historyPath == "" {
+		historyPath = fmt.Sprintf("bank_server_limits_history_%d.jsonl", port)
+	}
+	err = engine.OpenHistory(historyPath)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	spendingLimits = engine
+	fmt.Printf("Loaded spending limits for %d tiers and %d accounts\n", len(config.Tiers), len(config.Accounts))
+}
+
+// function to get the tier an account falls under
+func tierOf(tier string) string {
+	_, known := openingPolicies[tier]
+	if !known {
+		return defaultTier
+	}
+	return tier
+}
+
+// function to compute the opening balance of a new account
+func openingBalance(tier string) float64 {
+	return openingPolicies[tierOf(tier)].OpeningBalance
+}
+
+// function to open the log spool, LOG_SPOOL_DIR moves it
+// bank servers sharing a host need a spool each, so the default one is named after the port
+func openLogShipper(port int) {
+	dir := os.Getenv("LOG_SPOOL_DIR")
+	if dir == "" {
+		dir = fmt.Sprintf("bank_server_log_spool_%d", port)
+	}
+
+	shipper, err := logship.Open(dir, transactionLoggerAddress, transportSecurity, serviceIdentity)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	logShipper = shipper
+	fmt.Printf("Log spool opened, %d entries still to ship\n", shipper.Pending())
+}
+
+// function to record money that didn't come from a payment in the ledger
+// the entry is on disk in the spool once this returns, the shipper gets it to the logger
+func recordLedgerEntry(entryId, accountNumber string, amount float64, status, reason string) {
+	err := logShipper.Log(&pb.LogEntry{
+		TransactionId: entryId,
+		ClientId:      accountNumber,
+		Amount:        amount,
+		Status:        status,
+		Timestamp:     time.Now().Format(time.RFC3339),
+		Reason:        reason,
+	})
+	if err != nil {
+		fmt.Println("Failed to record ledger entry:", err)
+	}
+}
+
+// function to publish money leaving or reaching an account for a payment
+func publishTransferEvent(eventType string, event *pb.PaymentEvent) {
+	event.Type = eventType
+	err := eventbus.PublishPayment(eventBus, event)
+	if err != nil {
+		fmt.Printf("Failed to publish %s for %s: %v\n", eventType, event.TransactionId, err)
+	}
+}
+
 // function to abort the transaction for this server bank
 func (b *BankServer) AbortTransaction(ctx context.Context, req *pb.TransactionID) (*pb.Response, error) {
 	log.Printf("Transaction %s aborted: Rolling back changes", req.TransactionId)
@@ -70,7 +270,7 @@ func (ser *BankServer) updateCpuUsage() {
 			continue
 		}
 		//load balancer
-		conn, err := grpc.Dial(bankLoadBalancerAddress, grpc.WithInsecure())
+		conn, err := grpc.Dial(bankLoadBalancerAddress, transportSecurity, serviceIdentity)
 		if err != nil {
 			fmt.Println("Failed to connect to auth load balancer")
 			continue
@@ -93,7 +293,7 @@ func (ser *BankServer) updateCpuUsage() {
 
 // function to register this server with bank load balancer
 func registerWithBankLoadBalancer(address string) {
-	conn, err := grpc.Dial(bankLoadBalancerAddress, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := grpc.Dial(bankLoadBalancerAddress, transportSecurity, serviceIdentity, grpc.WithBlock())
 	if err != nil {
 		log.Fatalf("Failed to connect to bank load balancer: %v", err)
 	}
@@ -111,40 +311,292 @@ func registerWithBankLoadBalancer(address string) {
 }
 
 // function to register the user with bank
+// safe to retry: opening the same account for the same user again is a no-op
 func (ba *BankServer) RegisterUser(ctx context.Context, req *pb.ClientDetails) (*pb.Response, error) {
 	mute.Lock()
 	defer mute.Unlock()
 
-	_, exists := bankAccounts[req.AccountNumber]
+	if err := accountnum.Validate(req.AccountNumber); err != nil {
+		return &pb.Response{Status: "Invalid Account Number"}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	account, exists := bankAccounts[req.AccountNumber]
 	if exists {
-		return &pb.Response{Status: "User Already Registered in bank"}, nil
+		if account.owner == req.Username {
+			return &pb.Response{Status: "User Already Registered in bank"}, nil
+		}
+		return &pb.Response{Status: "Account Number Taken"}, status.Errorf(codes.AlreadyExists, "Account number %s already belongs to another user", req.AccountNumber)
+	}
+
+	tier := tierOf(req.Tier)
+	bankAccounts[req.AccountNumber] = &bankAccount{
+		owner:   req.Username,
+		balance: openingBalance(tier),
+		status:  accountActive,
+		tier:    tier,
 	}
 
-	bankAccounts[req.AccountNumber] = 100 //100 rupees as a gift to open account with Suyash Bank of India
+	fmt.Printf("Bank Account Created: Username: %s | Email: %s | Account: %s | Tier: %s | Balance: ₹%.2f\n",
+		req.Username, req.Email, req.AccountNumber, tier, bankAccounts[req.AccountNumber].balance)
 
-	fmt.Printf("Bank Account Created: Username: %s | Email: %s | Account: %s | Balance: ₹%.2f\n",
-		req.Username, req.Email, req.AccountNumber, bankAccounts[req.AccountNumber])
+	if bankAccounts[req.AccountNumber].balance > 0 {
+		recordLedgerEntry("open-"+req.AccountNumber, req.AccountNumber, bankAccounts[req.AccountNumber].balance, "Opening Balance", "OPENING_BALANCE")
+	}
 
 	return &pb.Response{Status: "Bank account created successfully!"}, nil
 }
 
+// function to move an account to another tier, the auth server calls it for AssignTier
+// the opening balance stays as it was, spending limits of the new tier apply from the next debit
+func (ba *BankServer) SetAccountTier(ctx context.Context, req *pb.TierAssignment) (*pb.Response, error) {
+	mute.Lock()
+	defer mute.Unlock()
+
+	_, known := openingPolicies[req.Tier]
+	if !known {
+		return &pb.Response{Status: "Unknown Tier"}, status.Errorf(codes.InvalidArgument, "Unknown tier %q", req.Tier)
+	}
+
+	account, exists := bankAccounts[req.AccountNumber]
+	if !exists || account.owner != req.Username {
+		return &pb.Response{Status: "Account Not Found"}, status.Errorf(codes.NotFound, "Account %s of %s not found", req.AccountNumber, req.Username)
+	}
+
+	log.Printf("Account %s moved from tier %s to %s", req.AccountNumber, account.tier, req.Tier)
+	account.tier = req.Tier
+	return &pb.Response{Status: "Tier changed"}, nil
+}
+
+// function to undo an account opening, compensation step of the registration saga
+// only untouched accounts of the same owner can be revoked, revoking twice is fine
+func (ba *BankServer) RevokeAccount(ctx context.Context, req *pb.ClientDetails) (*pb.Response, error) {
+	mute.Lock()
+	defer mute.Unlock()
+
+	account, exists := bankAccounts[req.AccountNumber]
+	if !exists {
+		return &pb.Response{Status: "Account already revoked"}, nil
+	}
+
+	if account.owner != req.Username {
+		return &pb.Response{Status: "Revoke Failed"}, status.Errorf(codes.PermissionDenied, "Account %s does not belong to %s", req.AccountNumber, req.Username)
+	}
+	if account.touched {
+		return &pb.Response{Status: "Revoke Failed"}, status.Errorf(codes.FailedPrecondition, "Account %s already has transactions", req.AccountNumber)
+	}
+
+	delete(bankAccounts, req.AccountNumber)
+	fmt.Printf("Bank Account Revoked: Username: %s | Account: %s\n", req.Username, req.AccountNumber)
+
+	return &pb.Response{Status: "Account revoked"}, nil
+}
+
+// function to list every account with its owner, used by the reconciliation job
+func (ba *BankServer) ListAccounts(ctx context.Context, req *pb.Empty) (*pb.AccountList, error) {
+	mute.Lock()
+	defer mute.Unlock()
+
+	list := &pb.AccountList{}
+	for number, account := range bankAccounts {
+		list.Accounts = append(list.Accounts, &pb.AccountRecord{AccountNumber: number, Username: account.owner, Status: account.status})
+	}
+	return list, nil
+}
+
+// function to reject amounts that would move money the wrong way, or corrupt the balance as NaN
+func checkAmount(amount float64) error {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) || amount <= 0 {
+		return status.Errorf(codes.InvalidArgument, "Amount must be a positive number, got %v", amount)
+	}
+	return nil
+}
+
+// function to check money may leave this account, caller holds mute
+func checkDebitAllowed(accountNumber string, account *bankAccount) error {
+	if account.status != accountActive {
+		fmt.Printf("Rejected debit from %s account %s\n", account.status, accountNumber)
+		return status.Errorf(codes.FailedPrecondition, "Account %s is %s", accountNumber, account.status)
+	}
+	return nil
+}
+
+// function to check a debit against the spending limits, caller holds mute
+// every breach goes to the transaction logger so refused attempts show up next to payments
+func checkSpendingLimits(transactionId, accountNumber string, account *bankAccount, amount float64) error {
+	err := spendingLimits.Check(accountNumber, account.tier, amount, time.Now())
+	if err == nil {
+		return nil
+	}
+
+	var breach *limits.Breach
+	if errors.As(err, &breach) {
+		fmt.Printf("Rejected debit of %.2f from %s: %v\n", amount, accountNumber, breach)
+		entryId := transactionId
+		if entryId == "" {
+			entryId = "limit-" + uuid.New().String()
+		}
+		recordLedgerEntry(entryId, accountNumber, amount, "Limit Breached", breach.Rule)
+	}
+	return err
+}
+
+// function to get balance and status of an account, users only see their own
+func (b *BankServer) GetBalance(ctx context.Context, req *pb.AccountRequest) (*pb.BalanceResponse, error) {
+	if err := authee.AuthorizeAccount(ctx, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	mute.Lock()
+	defer mute.Unlock()
+
+	account, exists := bankAccounts[req.AccountNumber]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "Account %s not found", req.AccountNumber)
+	}
+
+	return &pb.BalanceResponse{AccountNumber: req.AccountNumber, Balance: account.balance, Status: account.status}, nil
+}
+
+// function to move an account from one status to another, caller decides which moves are legal
+func changeAccountStatus(ctx context.Context, req *pb.AccountStatusChange, from []string, to string) (*pb.Response, error) {
+	mute.Lock()
+	defer mute.Unlock()
+
+	account, exists := bankAccounts[req.AccountNumber]
+	if !exists {
+		return &pb.Response{Status: "Account Not Found"}, status.Errorf(codes.NotFound, "Account %s not found", req.AccountNumber)
+	}
+
+	if account.status == to {
+		return &pb.Response{Status: "Account already " + to}, nil
+	}
+
+	allowed := false
+	for _, current := range from {
+		if account.status == current {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return &pb.Response{Status: "Status change not allowed"}, status.Errorf(codes.FailedPrecondition, "Account %s is %s, can't become %s", req.AccountNumber, account.status, to)
+	}
+
+	if to == accountClosed && account.balance != 0 {
+		return &pb.Response{Status: "Balance not zero"}, status.Errorf(codes.FailedPrecondition, "Account %s still holds ₹%.2f", req.AccountNumber, account.balance)
+	}
+
+	changedBy := "unknown"
+	caller, ok := authee.CallerFromContext(ctx)
+	if ok {
+		changedBy = caller.Username
+		if caller.Service != "" {
+			changedBy = caller.Service
+		}
+	}
+
+	log.Printf("Account %s changed from %s to %s by %s, reason: %s", req.AccountNumber, account.status, to, changedBy, req.Reason)
+	account.status = to
+	return &pb.Response{Status: "Account " + to}, nil
+}
+
+// function to freeze an account, no money leaves it until unfrozen
+func (b *BankServer) FreezeAccount(ctx context.Context, req *pb.AccountStatusChange) (*pb.Response, error) {
+	return changeAccountStatus(ctx, req, []string{accountActive}, accountFrozen)
+}
+
+// function to unfreeze a frozen account
+func (b *BankServer) UnfreezeAccount(ctx context.Context, req *pb.AccountStatusChange) (*pb.Response, error) {
+	return changeAccountStatus(ctx, req, []string{accountFrozen}, accountActive)
+}
+
+// function to close an account for good, only once its balance is zero
+// owners close their own account, account managers any
+func (b *BankServer) CloseAccount(ctx context.Context, req *pb.AccountStatusChange) (*pb.Response, error) {
+	if err := authee.AuthorizeAccount(ctx, req.AccountNumber); err != nil {
+		return &pb.Response{Status: "Close Failed"}, err
+	}
+	return changeAccountStatus(ctx, req, []string{accountActive, accountFrozen}, accountClosed)
+}
+
+// function for operators to credit an account, e.g. seeding balances in a test environment
+// every funding needs a known reason code and is written to the ledger
+func (b *BankServer) FundAccount(ctx context.Context, req *pb.FundingRequest) (*pb.DepositResponse, error) {
+	if err := checkAmount(req.Amount); err != nil {
+		return &pb.DepositResponse{Success: false, NewBalance: -1}, err
+	}
+	if !fundingReasons[req.ReasonCode] {
+		return &pb.DepositResponse{Success: false, NewBalance: -1}, status.Errorf(codes.InvalidArgument, "Unknown funding reason code %q", req.ReasonCode)
+	}
+
+	mute.Lock()
+	account, exists := bankAccounts[req.AccountNumber]
+	if !exists {
+		mute.Unlock()
+		return &pb.DepositResponse{Success: false, NewBalance: -1}, status.Errorf(codes.NotFound, "Account %s not found", req.AccountNumber)
+	}
+	if account.status == accountClosed {
+		mute.Unlock()
+		return &pb.DepositResponse{Success: false, NewBalance: -1}, status.Errorf(codes.FailedPrecondition, "Account %s is closed", req.AccountNumber)
+	}
+
+	account.balance += req.Amount
+	account.touched = true
+	newBalance := account.balance
+	mute.Unlock()
+
+	fundedBy := "unknown"
+	caller, ok := authee.CallerFromContext(ctx)
+	if ok {
+		fundedBy = caller.Username
+	}
+
+	entryId := "fund-" + uuid.New().String()
+	log.Printf("Account %s funded with %.2f by %s, reason: %s, reference: %s, entry: %s",
+		req.AccountNumber, req.Amount, fundedBy, req.ReasonCode, req.Reference, entryId)
+	recordLedgerEntry(entryId, req.AccountNumber, req.Amount, "Funded", req.ReasonCode)
+
+	return &pb.DepositResponse{Success: true, NewBalance: newBalance}, nil
+}
+
 // function to check whether user has enough amount or not
 func (b *BankServer) HasEnoughMoney(ctx context.Context, req *pb.MoneyRequest) (*pb.MoneyResponse, error) {
 	mute.Lock()
 	defer mute.Unlock()
 
-	balance, exists := bankAccounts[req.AccountNumber]
+	if err := accountnum.Validate(req.AccountNumber); err != nil {
+		return &pb.MoneyResponse{Approved: false, Balance: 0}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := checkAmount(req.Amount); err != nil {
+		return &pb.MoneyResponse{Approved: false, Balance: 0}, err
+	}
+
+	account, exists := bankAccounts[req.AccountNumber]
 	if !exists {
 		fmt.Println("Error:Account not found!")
 		return &pb.MoneyResponse{Approved: false, Balance: 0}, fmt.Errorf("Account not found")
 	}
 
+	//a replay of a payment already debited here must go through to its (no-op) commit
+	//even if the account was frozen or hit a limit since, the money already left
+	if req.TransactionId != "" && appliedTransfers[debitKey(req.TransactionId)] {
+		return &pb.MoneyResponse{Approved: true, Balance: account.balance}, nil
+	}
+
+	//frozen and closed accounts never vote yes in 2PC prepare
+	if err := checkDebitAllowed(req.AccountNumber, account); err != nil {
+		return &pb.MoneyResponse{Approved: false, Balance: account.balance}, err
+	}
+
+	if err := checkSpendingLimits(req.TransactionId, req.AccountNumber, account, req.Amount); err != nil {
+		return &pb.MoneyResponse{Approved: false, Balance: account.balance}, err
+	}
+
 	approved := false
-	if balance > req.Amount {
+	if account.balance > req.Amount {
 		approved = true
 	}
 
-	return &pb.MoneyResponse{Approved: approved, Balance: balance}, nil
+	return &pb.MoneyResponse{Approved: approved, Balance: account.balance}, nil
 }
 
 //function to deduct amount
@@ -153,17 +605,48 @@ func (b *BankServer) DeductMoney(ctx context.Context, req *pb.DeductRequest) (*p
 	mute.Lock()
 	defer mute.Unlock()
 
-	moneyLeft, err := b.HasEnoughMoney(ctx, &pb.MoneyRequest{
-		AccountNumber: req.AccountNumber,
-		Amount:        req.Amount,
-	})
+	if err := accountnum.Validate(req.AccountNumber); err != nil {
+		return &pb.DeductResponse{Success: false}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	//a negative debit would be a credit nobody checks
+	if err := checkAmount(req.Amount); err != nil {
+		return &pb.DeductResponse{Success: false}, err
+	}
 
-	if err != nil {
+	//mute is already held here, so look at the balance directly instead of calling HasEnoughMoney
+	account, exists := bankAccounts[req.AccountNumber]
+	if !exists {
+		return &pb.DeductResponse{Success: false}, fmt.Errorf("Account not found")
+	}
+
+	//the caller may retry after a timeout that hid our success, answer the same without debiting again
+	//checked first, a freeze or a limit reached since then doesn't undo a debit already made
+	if req.TransactionId != "" && appliedTransfers[debitKey(req.TransactionId)] {
+		fmt.Printf("Transaction %s already debited, nothing to do\n", req.TransactionId)
+		return &pb.DeductResponse{Success: true}, nil
+	}
+
+	//covers 2PC commit and offline queue retries as both end up here
+	if err := checkDebitAllowed(req.AccountNumber, account); err != nil {
+		return &pb.DeductResponse{Success: false}, err
+	}
+
+	//checked again on commit, other debits may have landed since prepare
+	if err := checkSpendingLimits(req.TransactionId, req.AccountNumber, account, req.Amount); err != nil {
 		return &pb.DeductResponse{Success: false}, err
 	}
 
-	if moneyLeft.Approved {
-		bankAccounts[req.AccountNumber] -= req.Amount
+	if account.balance > req.Amount {
+		account.balance -= req.Amount
+		account.touched = true
+		if req.TransactionId != "" {
+			appliedTransfers[debitKey(req.TransactionId)] = true
+			publishTransferEvent(eventbus.PaymentDebited, &pb.PaymentEvent{TransactionId: req.TransactionId, SenderId: req.AccountNumber, Amount: req.Amount})
+		}
+		if err := spendingLimits.Record(req.AccountNumber, req.Amount, time.Now()); err != nil {
+			//the debit is done, it still counts until this server restarts
+			fmt.Printf("Failed to keep debit of %s in limits history: %v\n", req.TransactionId, err)
+		}
 		fmt.Printf("Deducted %.2f amount. \n", req.Amount)
 		return &pb.DeductResponse{Success: true}, nil
 	}
@@ -176,27 +659,100 @@ func (b *BankServer) DepositMoney(ctx context.Context, req *pb.DepositRequest) (
 	mute.Lock()
 	defer mute.Unlock()
 
-	_, exists := bankAccounts[req.AccountNumber]
+	if err := accountnum.Validate(req.AccountNumber); err != nil {
+		return &pb.DepositResponse{Success: false, NewBalance: -1}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := checkAmount(req.Amount); err != nil {
+		return &pb.DepositResponse{Success: false, NewBalance: -1}, err
+	}
+
+	account, exists := bankAccounts[req.AccountNumber]
 
 	if !exists {
 		return &pb.DepositResponse{Success: false, NewBalance: -1}, fmt.Errorf("Account Not Found!")
 	}
 
-	bankAccounts[req.AccountNumber] += req.Amount
+	//frozen accounts still receive money, closed ones don't
+	if account.status == accountClosed {
+		return &pb.DepositResponse{Success: false, NewBalance: -1}, status.Errorf(codes.FailedPrecondition, "Account %s is closed", req.AccountNumber)
+	}
+
+	//2PC took this credit back to refund the sender, it must never land after all
+	if req.TransactionId != "" && appliedTransfers[reversalKey(req.TransactionId, req.AccountNumber)] {
+		return &pb.DepositResponse{Success: false, NewBalance: -1}, status.Errorf(codes.FailedPrecondition, "Credit of %s to %s was reversed", req.TransactionId, req.AccountNumber)
+	}
+
+	if req.TransactionId != "" && appliedTransfers[creditKey(req.TransactionId, req.AccountNumber)] {
+		fmt.Printf("Transaction %s already credited to %s, nothing to do\n", req.TransactionId, req.AccountNumber)
+		return &pb.DepositResponse{Success: true, NewBalance: account.balance}, nil
+	}
+
+	account.balance += req.Amount
+	account.touched = true
+	if req.TransactionId != "" {
+		appliedTransfers[creditKey(req.TransactionId, req.AccountNumber)] = true
+		publishTransferEvent(eventbus.PaymentCredited, &pb.PaymentEvent{TransactionId: req.TransactionId, ReceiverId: req.AccountNumber, Amount: req.Amount})
+	}
 
 	fmt.Printf("Deposited Money %.2f into Account %s | New Balance: ₹%.2f\n",
-		req.Amount, req.AccountNumber, bankAccounts[req.AccountNumber])
+		req.Amount, req.AccountNumber, account.balance)
 
-	return &pb.DepositResponse{Success: true, NewBalance: bankAccounts[req.AccountNumber]}, nil
+	return &pb.DepositResponse{Success: true, NewBalance: account.balance}, nil
+}
+
+// function to take back a credit of a payment 2PC is refunding
+// a credit which never arrived is barred from arriving later, reversing twice is a no-op
+// the money is taken even if the receiver spent it meanwhile, it was never theirs to keep
+func (b *BankServer) ReverseDeposit(ctx context.Context, req *pb.DepositRequest) (*pb.DepositResponse, error) {
+	mute.Lock()
+	defer mute.Unlock()
+
+	if req.TransactionId == "" {
+		return &pb.DepositResponse{Success: false, NewBalance: -1}, status.Error(codes.InvalidArgument, "Only credits of a transaction can be reversed")
+	}
+	if err := checkAmount(req.Amount); err != nil {
+		return &pb.DepositResponse{Success: false, NewBalance: -1}, err
+	}
+
+	account, exists := bankAccounts[req.AccountNumber]
+	if !exists {
+		return &pb.DepositResponse{Success: false, NewBalance: -1}, status.Errorf(codes.NotFound, "Account %s not found", req.AccountNumber)
+	}
+
+	if appliedTransfers[reversalKey(req.TransactionId, req.AccountNumber)] {
+		return &pb.DepositResponse{Success: true, NewBalance: account.balance}, nil
+	}
+	appliedTransfers[reversalKey(req.TransactionId, req.AccountNumber)] = true
+
+	if appliedTransfers[creditKey(req.TransactionId, req.AccountNumber)] {
+		account.balance -= req.Amount
+		fmt.Printf("Reversed credit of %.2f to %s for %s | New Balance: ₹%.2f\n", req.Amount, req.AccountNumber, req.TransactionId, account.balance)
+	}
+
+	return &pb.DepositResponse{Success: true, NewBalance: account.balance}, nil
 }
 
 func main() {
+	//service tokens can't be signed or checked without the shared secret
+	if err := authee.LoadServiceSecret(); err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	listen, err := net.Listen("tcp", ":0")
 	if err != nil {
 		log.Fatalf("Failed to listen!")
 	}
 
+	loadOpeningPolicies()
+
+	eventBus, err = eventbus.OpenDefault()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	port := listen.Addr().(*net.TCPAddr).Port
+	loadSpendingLimits(port)
+	openLogShipper(port)
 	addressH := fmt.Sprintf("localhost:%d", port)
 	bankServer := BankServer{address: addressH}
 
@@ -205,7 +761,9 @@ func main() {
 	//now report the cpu usage to bank load balancer
 	go bankServer.updateCpuUsage()
 
-	grpcServer := grpc.NewServer()
+	//deposits need an admin token, other rpcs only accept our own services
+	grpcServer := grpc.NewServer(grpc.Creds(tlsutil.ServerCredentials(authee.ServiceBankServer)), grpc.UnaryInterceptor(authee.AuthInterceptor), grpc.StreamInterceptor(authee.StreamAuthInterceptor))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
 	pb.RegisterBankServerServer(grpcServer, &BankServer{})
 
 	fmt.Println("Bank Server running on port ", port)
@@ -2,24 +2,23 @@ package main
 
 import (
 	pb "assignment_2/proto"
+	"assignment_2/tlsutil"
 	"context"
 	"fmt"
 	"log"
-	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
 var authLoadBalancerAddressForClient = "localhost:50055"
 var paymentGatewayForClient = "localhost:50052"
-var twoPhaseCommitServerForClient = "localhost:50057"
-var transactionLoggerForClient = "localhost:50058"
-var offlineQueueHandlerForClient = "localhost:50059"
+var bankLoadBalancerForClient = "localhost:50056"
 
 // function that will create grpc connection
 func connectGRPC(address string) (*grpc.ClientConn, error) {
-	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(tlsutil.ClientCredentials("client")), grpc.WithBlock())
 	if err != nil {
 		return nil, fmt.Errorf("Failed to connect to %s: %v", address, err)
 	}
@@ -53,10 +52,43 @@ func loginUser(authClient pb.AuthenticationClient, username, password string) st
 		log.Fatalf("Login failed :%v", err)
 	}
 
+	//account has a second factor, ask for the code from the authenticator app
+	if resp.MfaRequired {
+		var code string
+		fmt.Print("Enter totp code: ")
+		fmt.Scanln(&code)
+
+		resp, err = authClient.VerifyTOTP(context.Background(), &pb.TOTPVerification{
+			ChallengeId: resp.ChallengeId,
+			Code:        code,
+		})
+		if err != nil {
+			log.Fatalf("Totp verification failed :%v", err)
+		}
+	}
+
 	fmt.Println("Login successfully done!")
 	return resp.Token
 }
 
+// function to register and login in one go
+func loginUserAfterRegister(authClient pb.AuthenticationClient, username, password, email string) string {
+	registerUser(authClient, username, password, email)
+	return loginUser(authClient, username, password)
+}
+
+// account number is carried in our own token, no need to verify the signature here
+func accountNumberFromToken(token string) string {
+	claims := jwt.MapClaims{}
+	_, _, err := jwt.NewParser().ParseUnverified(token, claims)
+	if err != nil {
+		log.Fatalf("Failed to read token: %v", err)
+	}
+
+	accountNumber, _ := claims["accountNumber"].(string)
+	return accountNumber
+}
+
 // **Function to create a gRPC context with the token**
 func withAuthToken(token string) context.Context {
 	md := metadata.New(map[string]string{"authorization": "Bearer " + token})
@@ -64,7 +96,7 @@ func withAuthToken(token string) context.Context {
 }
 
 // initiating a transaction sir
-func initiateTransaction(pgClient pb.PaymentGatewayClient, token, sender, reciever string, amount float64) string {
+func initiateTransaction(pgClient pb.PaymentGatewayClient, token, sender, reciever string, amount float64) (string, string) {
 	ctx := withAuthToken(token)
 	resp, err := pgClient.InitiateTransaction(ctx, &pb.TransactionRequest{
 		SenderId:   sender,
@@ -75,46 +107,22 @@ func initiateTransaction(pgClient pb.PaymentGatewayClient, token, sender, reciev
 	if err != nil {
 		log.Fatalf("Failed to initiate a transaction: %v", err)
 	}
-	fmt.Println("Transaction initiated sir: ", resp.TransactionId)
-	return resp.TransactionId
+	fmt.Printf("Transaction initiated sir: %s | Status: %s | Risk score: %d\n", resp.TransactionId, resp.Status, resp.RiskScore)
+	return resp.TransactionId, resp.Status
 }
 
-// exexcuting 2 phase commit
-func executeTwoPhaseCommit(tpClient pb.TwoPhaseCommitClient, token, transactionId, senderId, receiverId string, amount float64) {
+// confirming the payment, the gateway runs 2 phase commit for it
+// if the bank can't take it right now the gateway queues it and retries on its own
+func confirmTransaction(pgClient pb.PaymentGatewayClient, token, transactionId string) {
 	ctx := withAuthToken(token)
-	//Phase 1: ready
-	voteResp, err := tpClient.ReadyToCommitTransaction(ctx, &pb.TransactionDetails{
+	resp, err := pgClient.ConfirmTransaction(ctx, &pb.TransactionConfirmation{
 		TransactionId: transactionId,
-		SenderId:      senderId,
-		ReceiverId:    receiverId,
-		Amount:        amount,
+		Success:       true,
 	})
 	if err != nil {
-		log.Fatalf("Ready to commit transaction failed: %v", err)
-	}
-
-	//Phase 2: abort or commit
-	//means commit (all are ready)
-	if voteResp.FinalDecision {
-		commitResp, err := tpClient.CommitTransaction(context.Background(), &pb.TransactionDetails{
-			TransactionId: transactionId,
-			SenderId:      senderId,
-			ReceiverId:    receiverId,
-			Amount:        amount,
-		})
-		if err != nil {
-			log.Fatalf("Failed to commit: %v", err)
-		}
-		fmt.Println("Successfully commited: ", commitResp)
-	} else {
-		abortResp, err := tpClient.AbortTransaction(context.Background(), &pb.TransactionID{TransactionId: transactionId})
-
-		if err != nil {
-			log.Fatalf("Failed to abort: %v", err)
-		}
-		fmt.Println("Successfully aborted: ", abortResp)
+		log.Fatalf("Failed to confirm the transaction: %v", err)
 	}
-
+	fmt.Println("Transaction confirmed: ", resp.Status)
 }
 
 // fetching transaction status
@@ -128,40 +136,34 @@ func getTransactionStatus(pgClient pb.PaymentGatewayClient, token, transactionId
 	fmt.Println("Transaction status: ", resp.Status)
 }
 
-// processing offline transactions
-func processOfflineTransactions(token string) {
-	offlineConn, err := connectGRPC(offlineQueueHandlerForClient)
+// fetching balance from the bank server owning the account
+func getBalance(token, accountNumber string) {
+	ctx := withAuthToken(token)
+
+	lbConn, err := connectGRPC(bankLoadBalancerForClient)
 	if err != nil {
-		log.Fatalf("Failed to connect to Offline Queue Handler: %v", err)
+		log.Fatalf("Failed to connect to Bank Load Balancer: %v", err)
 	}
-	defer offlineConn.Close()
+	defer lbConn.Close()
 
-	// Create a client for the OfflineQueueService.
-	offlineClient := pb.NewOfflineQueueServiceClient(offlineConn)
-
-	ctx := withAuthToken(token)
-	_, err = offlineClient.ProcessQueuedPayments(ctx, &pb.OfflineRequest{})
+	shard, err := pb.NewBankLoadBalancerClient(lbConn).GetAccountShard(ctx, &pb.AccountRequest{AccountNumber: accountNumber})
 	if err != nil {
-		log.Fatalf("Failed to process offline transactions: %v", err)
+		fmt.Println("Failed to find bank server of account: ", err)
+		return
 	}
-	fmt.Println("Offline transactions processed successfully.")
-}
 
-// log transactions to that file
-func logTransactions(loggerCLient pb.LoggingServiceClient, token, transactionId, clientId string, amount float64, status string) {
-	ctx := withAuthToken(token)
-	_, err := loggerCLient.LogTransaction(ctx, &pb.LogEntry{
-		TransactionId: transactionId,
-		ClientId:      clientId,
-		Amount:        amount,
-		Status:        status,
-		Timestamp:     time.Now().Format(time.RFC3339),
-	})
+	bankConn, err := connectGRPC(shard.Address)
+	if err != nil {
+		log.Fatalf("Failed to connect to Bank Server: %v", err)
+	}
+	defer bankConn.Close()
 
+	resp, err := pb.NewBankServerClient(bankConn).GetBalance(ctx, &pb.AccountRequest{AccountNumber: accountNumber})
 	if err != nil {
-		fmt.Println("Failed to log transactions", err)
+		fmt.Println("Failed to get balance: ", err)
+		return
 	}
-	fmt.Println("Transaction Logged: ", transactionId, "| Status: ", status)
+	fmt.Printf("Balance of %s: %.2f (%s)\n", resp.AccountNumber, resp.Balance, resp.Status)
 }
 
 func main() {
@@ -195,20 +197,6 @@ func main() {
 	defer pgConn.Close()
 	pgClient := pb.NewPaymentGatewayClient(pgConn)
 
-	tpConn, err := connectGRPC(twoPhaseCommitServerForClient)
-	if err != nil {
-		log.Fatalf("Failed to connect to Two Phase Commit Server: %v", err)
-	}
-	defer tpConn.Close()
-	tpClient := pb.NewTwoPhaseCommitClient(tpConn)
-
-	loggerConn, err := connectGRPC(transactionLoggerForClient)
-	if err != nil {
-		log.Fatalf("Failed to connect to Transaction Logger: %v", err)
-	}
-	defer loggerConn.Close()
-	loggerClient := pb.NewLoggingServiceClient(loggerConn)
-
 	// Register user
 	userName := "Suyash"
 	passWord := "Suyash"
@@ -218,19 +206,23 @@ func main() {
 	// Login user
 	token := loginUser(authClient, userName, passWord)
 
-	senderId := "SBI12345"
-	receiverId := "SBI67890"
-	amount := 100.0
+	// Register and login the receiver, only to learn its account number
+	receiverToken := loginUserAfterRegister(authClient, "Ravi", "Ravi", "ravi@example.com")
 
-	transactionId := initiateTransaction(pgClient, token, userName, "user", 100.0)
+	senderId := accountNumberFromToken(token)
+	receiverId := accountNumberFromToken(receiverToken)
+	amount := 50.0
 
-	executeTwoPhaseCommit(tpClient, token, transactionId, senderId, receiverId, amount)
+	transactionId, transactionStatus := initiateTransaction(pgClient, token, senderId, receiverId, amount)
 
-	getTransactionStatus(pgClient, token, transactionId)
+	//payments held by the fraud rules wait for an operator instead
+	if transactionStatus == "Pending" {
+		confirmTransaction(pgClient, token, transactionId)
+	}
 
-	logTransactions(loggerClient, token, transactionId, userName, 100.0, "Committed")
+	getTransactionStatus(pgClient, token, transactionId)
 
-	processOfflineTransactions(token)
+	getBalance(token, senderId)
 
 	fmt.Println("Client executed successfully!")
 }
@@ -0,0 +1,43 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// returned by Subscribe when another consumer of the same group is already reading the topic
+var ErrGroupBusy = errors.New("consumer group already has a consumer")
+
+// one message on a topic, Offset is its place in the partition
+type Event struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	//events with the same key land in the same partition, in the order they were published
+	Key     string
+	Payload []byte
+	At      time.Time
+}
+
+// function to name an event uniquely, redeliveries of it carry the same id
+func (e Event) Id() string {
+	return fmt.Sprintf("%s/%d/%d", e.Topic, e.Partition, e.Offset)
+}
+
+// function run for each event of a subscription, an error hands the same event over again after a backoff
+type Handler func(ctx context.Context, event Event) error
+
+// publishes events to topics split in partitions, and hands them to consumer groups
+//
+// every group keeps its own offset per partition, so each group sees every event of a
+// topic and groups don't hold each other up. an offset moves past an event only once its
+// handler returned nil, so events are delivered at least once and handlers must be safe
+// to run twice for the same event, Event.Id tells a redelivery apart.
+type Bus interface {
+	Publish(topic, key string, payload []byte) error
+	//blocks, handing events over until ctx is done
+	Subscribe(ctx context.Context, topic, group string, handler Handler) error
+	Close() error
+}
@@ -0,0 +1,399 @@
+package eventbus
+
+import (
+	"assignment_2/retry"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// partitions of a topic created by this bus, a topic keeps the count it was created with
+const DefaultPartitions = 4
+
+// how often a subscription looks for new events
+const PollInterval = 200 * time.Millisecond
+
+// files inside the directory of a topic
+const (
+	partitionsFile = "partitions"
+	groupsDir      = "groups"
+	partitionExt   = ".log"
+)
+
+// topic and group names become file names
+var validName = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*$`)
+
+// one line of a partition file
+type record struct {
+	Key     string    `json:"key"`
+	At      time.Time `json:"at"`
+	Payload []byte    `json:"payload"`
+}
+
+// a partition file opened for appending, mu keeps goroutines of this process apart as
+// the file lock only keeps processes apart
+type appender struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// event bus kept in a directory, shared by every service on the host that opens the same one
+//
+// a topic is a directory with one append-only json lines file per partition, the offset of an
+// event is the byte offset of its line. publishers lock the partition file while they append,
+// so services may publish to the same topic at once. committed offsets of a consumer group are
+// kept in groups/<group>.json inside the topic, and a lock on groups/<group>.lock keeps a
+// second consumer of the group out. nothing is ever deleted.
+type FileBus struct {
+	dir        string
+	partitions int
+	backoff    retry.Backoff
+
+	mu sync.Mutex
+	//partitions per topic, read once
+	topics    map[string]int
+	appenders map[string]*appender
+}
+
+// function to open the bus in dir, creating it if needed
+// partitions <= 0 means DefaultPartitions, it only matters for topics this bus creates
+func Open(dir string, partitions int) (*FileBus, error) {
+	if partitions <= 0 {
+		partitions = DefaultPartitions
+	}
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create event bus dir: %v", err)
+	}
+	return &FileBus{
+		dir:        dir,
+		partitions: partitions,
+		backoff:    retry.Backoff{Base: 500 * time.Millisecond, Max: 30 * time.Second},
+		topics:     make(map[string]int),
+		appenders:  make(map[string]*appender),
+	}, nil
+}
+
+// function to open the bus every service shares, EVENT_BUS_DIR moves it (default event_bus)
+func OpenDefault() (*FileBus, error) {
+	dir := os.Getenv("EVENT_BUS_DIR")
+	if dir == "" {
+		dir = "event_bus"
+	}
+	return Open(dir, 0)
+}
+
+// function to pick the partition of a key, the same key always gets the same one
+func partitionOf(key string, partitions int) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(partitions))
+}
+
+func (b *FileBus) partitionPath(topic string, partition int) string {
+	return filepath.Join(b.dir, topic, strconv.Itoa(partition)+partitionExt)
+}
+
+// function to get the partition count of a topic, creating the topic if it isn't there yet
+func (b *FileBus) topicPartitions(topic string) (int, error) {
+	if !validName.MatchString(topic) {
+		return 0, fmt.Errorf("Invalid topic name %q", topic)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if n, ok := b.topics[topic]; ok {
+		return n, nil
+	}
+
+	topicDir := filepath.Join(b.dir, topic)
+	err := os.MkdirAll(filepath.Join(topicDir, groupsDir), 0700)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to create topic %s: %v", topic, err)
+	}
+
+	//written aside and linked in place, so two services creating the topic at once agree on one count
+	path := filepath.Join(topicDir, partitionsFile)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		tmp, err := os.CreateTemp(topicDir, partitionsFile+".tmp*")
+		if err != nil {
+			return 0, err
+		}
+		_, err = tmp.WriteString(strconv.Itoa(b.partitions))
+		if err == nil {
+			err = tmp.Sync()
+		}
+		tmp.Close()
+		if err == nil {
+			err = os.Link(tmp.Name(), path)
+		}
+		os.Remove(tmp.Name())
+		if err != nil && !errors.Is(err, os.ErrExist) {
+			return 0, fmt.Errorf("Failed to create topic %s: %v", topic, err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("Invalid partition count in %s", path)
+	}
+	b.topics[topic] = n
+	return n, nil
+}
+
+// function to get the open file of a partition to append to
+func (b *FileBus) appenderOf(topic string, partition int) (*appender, error) {
+	path := b.partitionPath(topic, partition)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if a, ok := b.appenders[path]; ok {
+		return a, nil
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	a := &appender{file: file}
+	b.appenders[path] = a
+	return a, nil
+}
+
+// function to append an event to the partition of its key, it is on disk once this returns
+func (b *FileBus) Publish(topic, key string, payload []byte) error {
+	partitions, err := b.topicPartitions(topic)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(record{Key: key, At: time.Now().UTC(), Payload: payload})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	a, err := b.appenderOf(topic, partitionOf(key, partitions))
+	if err != nil {
+		return fmt.Errorf("Failed to open partition: %v", err)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	fd := int(a.file.Fd())
+	err = syscall.Flock(fd, syscall.LOCK_EX)
+	if err != nil {
+		return fmt.Errorf("Failed to lock partition: %v", err)
+	}
+	defer syscall.Flock(fd, syscall.LOCK_UN)
+
+	//a publisher that died half way left a line without its newline, end it so ours starts on a line of its own
+	info, err := a.file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() > 0 {
+		last := make([]byte, 1)
+		_, err = a.file.ReadAt(last, info.Size()-1)
+		if err != nil {
+			return err
+		}
+		if last[0] != '\n' {
+			line = append([]byte{'\n'}, line...)
+		}
+	}
+
+	_, err = a.file.Write(line)
+	if err != nil {
+		return fmt.Errorf("Failed to publish to %s: %v", topic, err)
+	}
+	return a.file.Sync()
+}
+
+// function to hand every event of topic to handler, partition by partition in order, until ctx is done
+// the group starts at the beginning of the topic the first time it subscribes
+func (b *FileBus) Subscribe(ctx context.Context, topic, group string, handler Handler) error {
+	if !validName.MatchString(group) {
+		return fmt.Errorf("Invalid group name %q", group)
+	}
+	partitions, err := b.topicPartitions(topic)
+	if err != nil {
+		return err
+	}
+
+	groupBase := filepath.Join(b.dir, topic, groupsDir, group)
+	lock, err := os.OpenFile(groupBase+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer lock.Close()
+	err = syscall.Flock(int(lock.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return fmt.Errorf("%w: %s on %s", ErrGroupBusy, group, topic)
+	}
+	if err != nil {
+		return err
+	}
+
+	offsets, err := loadOffsets(groupBase + ".json")
+	if err != nil {
+		return err
+	}
+
+	for {
+		for partition := 0; partition < partitions; partition++ {
+			before := offsets[partition]
+			err := b.consume(ctx, topic, partition, offsets, handler)
+			if offsets[partition] != before {
+				errSave := saveOffsets(groupBase+".json", offsets)
+				if errSave != nil {
+					//the events are handed over again after a restart, handlers take that
+					fmt.Printf("Failed to commit offsets of group %s on %s: %v\n", group, topic, errSave)
+				}
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-time.After(PollInterval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// function to hand over the events of one partition past its offset, moving the offset past each one handled
+func (b *FileBus) consume(ctx context.Context, topic string, partition int, offsets map[int]int64, handler Handler) error {
+	file, err := os.Open(b.partitionPath(topic, partition))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	offset := offsets[partition]
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			//a line without its newline is still being written, it is read next time
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var r record
+		if json.Unmarshal(line, &r) != nil {
+			fmt.Printf("Skipped broken event at %s/%d/%d\n", topic, partition, offset)
+		} else {
+			event := Event{Topic: topic, Partition: partition, Offset: offset, Key: r.Key, Payload: r.Payload, At: r.At}
+			err := b.deliver(ctx, event, handler)
+			if err != nil {
+				return err
+			}
+		}
+		offset += int64(len(line))
+		offsets[partition] = offset
+	}
+}
+
+// function to run handler on an event until it takes it, returns only early when ctx is done
+func (b *FileBus) deliver(ctx context.Context, event Event, handler Handler) error {
+	failures := 0
+	for {
+		err := handler(ctx, event)
+		if err == nil {
+			return nil
+		}
+		failures++
+		delay := b.backoff.Delay(failures)
+		fmt.Printf("Failed to handle event %s, trying again in %v: %v\n", event.Id(), delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// function to read the committed offsets of a group, a group that never committed starts at 0
+func loadOffsets(path string) (map[int]int64, error) {
+	offsets := make(map[int]int64)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return offsets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &offsets)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid offsets in %s: %v", path, err)
+	}
+	return offsets, nil
+}
+
+// function to commit the offsets of a group, written aside and renamed so a crash keeps the old ones
+func saveOffsets(path string, offsets map[int]int64) error {
+	data, err := json.Marshal(offsets)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	file.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// function to close the partition files, subscriptions end with their ctx
+func (b *FileBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var firstErr error
+	for path, a := range b.appenders {
+		err := a.file.Close()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(b.appenders, path)
+	}
+	return firstErr
+}
@@ -0,0 +1,227 @@
+package eventbus
+
+import (
+	"assignment_2/retry"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// consumer running Subscribe in the background and keeping what it was handed
+type consumer struct {
+	mu     sync.Mutex
+	events []Event
+	cancel context.CancelFunc
+	done   chan error
+}
+
+// function to subscribe group in the background, handler may be nil to take everything
+func subscribe(bus *FileBus, topic, group string, handler Handler) *consumer {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &consumer{cancel: cancel, done: make(chan error, 1)}
+	go func() {
+		c.done <- bus.Subscribe(ctx, topic, group, func(ctx context.Context, event Event) error {
+			if handler != nil {
+				if err := handler(ctx, event); err != nil {
+					return err
+				}
+			}
+			c.mu.Lock()
+			c.events = append(c.events, event)
+			c.mu.Unlock()
+			return nil
+		})
+	}()
+	return c
+}
+
+func (c *consumer) seen() []Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Event(nil), c.events...)
+}
+
+// function to wait until the consumer was handed n events
+func (c *consumer) waitFor(t *testing.T, n int) []Event {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(c.seen()) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d events, want %d", len(c.seen()), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return c.seen()
+}
+
+// function to end the subscription, its offsets are committed by then
+func (c *consumer) stop(t *testing.T) {
+	t.Helper()
+	c.cancel()
+	if err := <-c.done; err != nil {
+		t.Fatalf("subscription ended with %v", err)
+	}
+}
+
+func openBus(t *testing.T, dir string, partitions int) *FileBus {
+	t.Helper()
+	bus, err := Open(dir, partitions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus.backoff = retry.Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}
+	return bus
+}
+
+func publish(t *testing.T, bus *FileBus, key string, payloads ...string) {
+	t.Helper()
+	for _, payload := range payloads {
+		if err := bus.Publish("orders", key, []byte(payload)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestEventsOfAKeyStayInOrder(t *testing.T) {
+	bus := openBus(t, t.TempDir(), 4)
+	defer bus.Close()
+	for i := 0; i < 10; i++ {
+		for _, key := range []string{"a", "b", "c"} {
+			publish(t, bus, key, fmt.Sprintf("%s-%d", key, i))
+		}
+	}
+
+	c := subscribe(bus, "orders", "g", nil)
+	events := c.waitFor(t, 30)
+	c.stop(t)
+
+	next := map[string]int{}
+	for _, event := range events {
+		want := fmt.Sprintf("%s-%d", event.Key, next[event.Key])
+		if string(event.Payload) != want {
+			t.Fatalf("got %s, want %s", event.Payload, want)
+		}
+		next[event.Key]++
+	}
+}
+
+func TestOffsetsSurviveRestart(t *testing.T) {
+	dir := t.TempDir()
+	bus := openBus(t, dir, 2)
+	publish(t, bus, "a", "1", "2", "3")
+	publish(t, bus, "b", "4", "5")
+
+	c := subscribe(bus, "orders", "g", nil)
+	c.waitFor(t, 5)
+	c.stop(t)
+	bus.Close()
+
+	//the service comes back, the group picks up where it left off
+	bus = openBus(t, dir, 2)
+	defer bus.Close()
+	publish(t, bus, "a", "6")
+	publish(t, bus, "b", "7")
+
+	c = subscribe(bus, "orders", "g", nil)
+	c.waitFor(t, 2)
+	//nothing older comes after them
+	time.Sleep(2 * PollInterval)
+	events := c.seen()
+	c.stop(t)
+	if len(events) != 2 {
+		t.Fatalf("after restart got %d events, want only the 2 new ones", len(events))
+	}
+
+	//a new group still starts at the beginning
+	c = subscribe(bus, "orders", "other", nil)
+	c.waitFor(t, 7)
+	c.stop(t)
+}
+
+func TestFailedEventIsHandedOverAgain(t *testing.T) {
+	bus := openBus(t, t.TempDir(), 1)
+	defer bus.Close()
+	publish(t, bus, "a", "1", "2")
+
+	var mu sync.Mutex
+	var attempts []string
+	failed := false
+	c := subscribe(bus, "orders", "g", func(ctx context.Context, event Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts = append(attempts, event.Id())
+		if !failed {
+			failed = true
+			return errors.New("not now")
+		}
+		return nil
+	})
+	events := c.waitFor(t, 2)
+	c.stop(t)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(attempts) != 3 || attempts[0] != attempts[1] || attempts[1] != events[0].Id() {
+		t.Fatalf("attempts %v, want the first event twice before the second", attempts)
+	}
+	if string(events[0].Payload) != "1" || string(events[1].Payload) != "2" {
+		t.Fatalf("events out of order after a failure: %s, %s", events[0].Payload, events[1].Payload)
+	}
+}
+
+func TestGroupHasOneConsumer(t *testing.T) {
+	dir := t.TempDir()
+	bus := openBus(t, dir, 1)
+	defer bus.Close()
+	publish(t, bus, "a", "1")
+
+	first := subscribe(bus, "orders", "g", nil)
+	first.waitFor(t, 1)
+
+	//a second process of the same group is turned away, another group is not
+	second := openBus(t, dir, 1)
+	defer second.Close()
+	err := second.Subscribe(context.Background(), "orders", "g", func(ctx context.Context, event Event) error { return nil })
+	if !errors.Is(err, ErrGroupBusy) {
+		t.Fatalf("second consumer of the group got %v, want ErrGroupBusy", err)
+	}
+	other := subscribe(second, "orders", "other", nil)
+	other.waitFor(t, 1)
+	other.stop(t)
+
+	//once the first one is gone the group can be taken over
+	first.stop(t)
+	publish(t, bus, "a", "2")
+	taken := subscribe(second, "orders", "g", nil)
+	if events := taken.waitFor(t, 1); string(events[0].Payload) != "2" {
+		t.Fatalf("taken over group got %s, want 2", events[0].Payload)
+	}
+	taken.stop(t)
+}
+
+func TestTornLineIsSkipped(t *testing.T) {
+	dir := t.TempDir()
+	bus := openBus(t, dir, 1)
+	defer bus.Close()
+	publish(t, bus, "a", "1")
+
+	//a publisher died half way through its line
+	file, err := os.OpenFile(bus.partitionPath("orders", 0), os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"key":"a","payl`)
+	file.Close()
+	publish(t, bus, "a", "2")
+
+	c := subscribe(bus, "orders", "g", nil)
+	events := c.waitFor(t, 2)
+	c.stop(t)
+	if string(events[0].Payload) != "1" || string(events[1].Payload) != "2" {
+		t.Fatalf("got %s, %s around the torn line", events[0].Payload, events[1].Payload)
+	}
+}
@@ -0,0 +1,45 @@
+package eventbus
+
+import (
+	pb "assignment_2/proto"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// topic of the payment lifecycle, keyed by transaction id so the events of a payment stay in order
+const TopicPayments = "payments"
+
+// payment lifecycle events, committed, aborted and refunded are the ones merchants get as webhooks
+const (
+	//gateway
+	PaymentInitiated = "payment.initiated"
+	PaymentHeld      = "payment.held"
+	PaymentDenied    = "payment.denied"
+	PaymentQueued    = "payment.queued"
+	//2PC
+	PaymentCommitted = "payment.committed"
+	PaymentAborted   = "payment.aborted"
+	PaymentRefunded  = "payment.refunded"
+	//bank, one per account money left or reached
+	PaymentDebited  = "payment.debited"
+	PaymentCredited = "payment.credited"
+)
+
+// function to publish a payment event on the payments topic
+func PublishPayment(bus Bus, event *pb.PaymentEvent) error {
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return bus.Publish(TopicPayments, event.TransactionId, payload)
+}
+
+// function to read the payment event out of an event of the payments topic
+func DecodePayment(event Event) (*pb.PaymentEvent, error) {
+	payment := &pb.PaymentEvent{}
+	err := proto.Unmarshal(event.Payload, payment)
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
@@ -0,0 +1,103 @@
+package fees
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+)
+
+// merchant payments with a fee can't commit until the fee has somewhere to go
+var ErrNoPlatformAccount = errors.New("No platform revenue account configured for merchant fees")
+
+// fee taken from one merchant payment, percentage of the amount plus a fixed part
+type Schedule struct {
+	Percentage float64 `json:"percentage"`
+	Fixed      float64 `json:"fixed"`
+}
+
+// fee schedules and where the fees go
+type Config struct {
+	//account of the platform receiving every fee
+	PlatformAccount string `json:"platformAccount"`
+	//used for merchants without their own schedule
+	Default   Schedule            `json:"default"`
+	Merchants map[string]Schedule `json:"merchants"`
+}
+
+// function to read a config file like
+//
+//	{"platformAccount": "1234567897", "default": {"percentage": 2.9, "fixed": 0.30},
+//	 "merchants": {"m_4f1c2a9e7b3d": {"percentage": 1.5, "fixed": 0}}}
+func LoadConfig(path string) (Config, error) {
+	var config Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("Failed to read fee schedule file: %v", err)
+	}
+
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return config, fmt.Errorf("Failed to parse fee schedule file: %v", err)
+	}
+
+	schedules := []Schedule{config.Default}
+	for _, schedule := range config.Merchants {
+		schedules = append(schedules, schedule)
+	}
+	for _, schedule := range schedules {
+		if schedule.Percentage < 0 || schedule.Percentage > 100 || schedule.Fixed < 0 {
+			return config, fmt.Errorf("Invalid fee schedule %+v", schedule)
+		}
+	}
+	return config, nil
+}
+
+// function to get the schedule of a merchant
+func (c Config) ScheduleFor(merchantId string) Schedule {
+	schedule, exists := c.Merchants[merchantId]
+	if exists {
+		return schedule
+	}
+	return c.Default
+}
+
+// function to split an amount into what the merchant gets and the platform fee
+// fee is rounded to cents and never more than the amount itself
+func (s Schedule) Split(amount float64) (float64, float64) {
+	fee := math.Round((amount*s.Percentage/100+s.Fixed)*100) / 100
+	if fee > amount {
+		fee = amount
+	}
+	return amount - fee, fee
+}
+
+// one account credited when a payment commits
+type Credit struct {
+	Account string
+	Amount  float64
+	//what the transaction log records for this credit
+	Status string
+}
+
+// function to work out who gets what, merchant payments give the platform its fee
+func (c Config) Credits(receiverId, merchantId string, amount float64) ([]Credit, error) {
+	if merchantId == "" {
+		return []Credit{{Account: receiverId, Amount: amount, Status: "Credited"}}, nil
+	}
+
+	payout, fee := c.ScheduleFor(merchantId).Split(amount)
+	credits := []Credit{}
+	if payout > 0 {
+		credits = append(credits, Credit{Account: receiverId, Amount: payout, Status: "Merchant Payout"})
+	}
+	if fee > 0 {
+		if c.PlatformAccount == "" {
+			return nil, ErrNoPlatformAccount
+		}
+		credits = append(credits, Credit{Account: c.PlatformAccount, Amount: fee, Status: "Platform Fee"})
+	}
+	return credits, nil
+}
@@ -0,0 +1,136 @@
+package fees
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestSplitRoundsFeeToCents(t *testing.T) {
+	cases := []struct {
+		name     string
+		schedule Schedule
+		amount   float64
+		fee      float64
+	}{
+		{"percentage and fixed", Schedule{Percentage: 2.9, Fixed: 0.30}, 100, 3.20},
+		{"rounds down", Schedule{Percentage: 2.9, Fixed: 0.30}, 10.05, 0.59},
+		{"rounds up", Schedule{Percentage: 2.9}, 10.30, 0.30},
+		{"below half a cent", Schedule{Percentage: 1}, 0.40, 0},
+		{"fixed only", Schedule{Fixed: 0.25}, 50, 0.25},
+		{"no fee", Schedule{}, 42.42, 0},
+	}
+
+	for _, c := range cases {
+		payout, fee := c.schedule.Split(c.amount)
+		if !near(fee, c.fee) || !near(payout+fee, c.amount) {
+			t.Errorf("%s: split %v into payout %v and fee %v, want fee %v", c.name, c.amount, payout, fee, c.fee)
+		}
+	}
+}
+
+func TestSplitCapsFeeAtFullAmount(t *testing.T) {
+	cases := []struct {
+		schedule Schedule
+		amount   float64
+	}{
+		{Schedule{Fixed: 0.30}, 0.20},
+		{Schedule{Percentage: 2.9, Fixed: 0.30}, 0.30},
+		{Schedule{Percentage: 100, Fixed: 1}, 5},
+	}
+
+	for _, c := range cases {
+		payout, fee := c.schedule.Split(c.amount)
+		if payout != 0 || fee != c.amount {
+			t.Errorf("%+v on %v: payout %v fee %v, want the whole amount as fee", c.schedule, c.amount, payout, fee)
+		}
+	}
+}
+
+func TestCreditsForMerchantPayment(t *testing.T) {
+	config := Config{
+		PlatformAccount: "platform",
+		Default:         Schedule{Percentage: 2.9, Fixed: 0.30},
+		Merchants:       map[string]Schedule{"m_cheap": {Percentage: 1}},
+	}
+
+	credits, err := config.Credits("shop", "m_other", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(credits) != 2 || credits[0].Account != "shop" || !near(credits[0].Amount, 96.80) ||
+		credits[1].Account != "platform" || !near(credits[1].Amount, 3.20) {
+		t.Fatalf("default schedule credits %+v", credits)
+	}
+
+	credits, _ = config.Credits("shop", "m_cheap", 100)
+	if len(credits) != 2 || !near(credits[0].Amount, 99) || !near(credits[1].Amount, 1) {
+		t.Fatalf("own schedule credits %+v", credits)
+	}
+
+	//whole payment goes on the fee, the merchant gets no zero credit
+	credits, _ = config.Credits("shop", "m_other", 0.10)
+	if len(credits) != 1 || credits[0].Account != "platform" || !near(credits[0].Amount, 0.10) {
+		t.Fatalf("fee capped credits %+v", credits)
+	}
+}
+
+func TestCreditsWithoutMerchantGoToReceiver(t *testing.T) {
+	credits, err := Config{}.Credits("friend", "", 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(credits) != 1 || credits[0].Account != "friend" || credits[0].Amount != 25 {
+		t.Fatalf("credits %+v", credits)
+	}
+}
+
+func TestCreditsNeedPlatformAccountForFee(t *testing.T) {
+	config := Config{Default: Schedule{Percentage: 2.9}}
+	if _, err := config.Credits("shop", "m_any", 100); !errors.Is(err, ErrNoPlatformAccount) {
+		t.Fatalf("got %v, want %v", err, ErrNoPlatformAccount)
+	}
+	//no fee, nothing to send to the platform
+	if _, err := (Config{}).Credits("shop", "m_any", 100); err != nil {
+		t.Fatalf("free merchant payment refused: %v", err)
+	}
+}
+
+func TestLoadConfigRefusesBadSchedules(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	good := write("good.json", `{"platformAccount": "p", "default": {"percentage": 2.9, "fixed": 0.30}, "merchants": {"m_a": {"percentage": 1.5}}}`)
+	config, err := LoadConfig(good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ScheduleFor("m_a").Percentage != 1.5 || config.ScheduleFor("m_b").Fixed != 0.30 {
+		t.Fatalf("loaded %+v", config)
+	}
+
+	for name, content := range map[string]string{
+		"over.json":     `{"default": {"percentage": 101}}`,
+		"negative.json": `{"merchants": {"m_a": {"fixed": -1}}}`,
+		"broken.json":   `{"default":`,
+	} {
+		if _, err := LoadConfig(write(name, content)); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+	if _, err := LoadConfig(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("missing file accepted")
+	}
+}
@@ -0,0 +1,132 @@
+package fraud
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// what the gateway does with a payment
+const (
+	DecisionAllow  = "allow"
+	DecisionReview = "review"
+	DecisionDeny   = "deny"
+)
+
+// the payment as seen by the rules
+type Payment struct {
+	Sender   string
+	Receiver string
+	Amount   float64
+	Currency string
+}
+
+// outcome of running every rule on a payment
+type Assessment struct {
+	Score    int
+	Decision string
+	//names of the rules that fired
+	Matched []string
+}
+
+// runs the rules and remembers enough history to judge the next payment
+type Engine struct {
+	mu     sync.Mutex
+	config Config
+	//sender : receivers it already paid
+	knownReceivers map[string]map[string]bool
+	//sender|receiver : times of recent payments
+	recent map[string][]time.Time
+	//longest rapid repeats window, older history is dropped
+	maxWindow time.Duration
+}
+
+func NewEngine(config Config) *Engine {
+	engine := &Engine{
+		config:         config,
+		knownReceivers: make(map[string]map[string]bool),
+		recent:         make(map[string][]time.Time),
+	}
+	for _, rule := range config.Rules {
+		if rule.Type == RuleRapidRepeats && time.Duration(rule.Window) > engine.maxWindow {
+			engine.maxWindow = time.Duration(rule.Window)
+		}
+	}
+	return engine
+}
+
+// function to score a payment, every call counts as an attempt for rapid repeats
+func (e *Engine) Evaluate(p Payment, now time.Time) Assessment {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	pair := p.Sender + "|" + p.Receiver
+	attempts := e.recent[pair]
+	keep := 0
+	for keep < len(attempts) && now.Sub(attempts[keep]) >= e.maxWindow {
+		keep++
+	}
+	attempts = attempts[keep:]
+
+	assessment := Assessment{}
+	for _, rule := range e.config.Rules {
+		if e.matches(rule, p, attempts, now) {
+			assessment.Score += rule.Score
+			assessment.Matched = append(assessment.Matched, rule.Name)
+		}
+	}
+
+	e.recent[pair] = append(attempts, now)
+
+	switch {
+	case assessment.Score >= e.config.DenyScore:
+		assessment.Decision = DecisionDeny
+	case assessment.Score >= e.config.ReviewScore:
+		assessment.Decision = DecisionReview
+	default:
+		assessment.Decision = DecisionAllow
+		e.trustLocked(p.Sender, p.Receiver)
+	}
+	return assessment
+}
+
+// function to remember a receiver as known, e.g. after an operator approved a held payment
+func (e *Engine) Trust(sender, receiver string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.trustLocked(sender, receiver)
+}
+
+func (e *Engine) trustLocked(sender, receiver string) {
+	if e.knownReceivers[sender] == nil {
+		e.knownReceivers[sender] = make(map[string]bool)
+	}
+	e.knownReceivers[sender][receiver] = true
+}
+
+// function to check one rule, attempts are earlier payments of the same pair, caller holds mu
+func (e *Engine) matches(rule Rule, p Payment, attempts []time.Time, now time.Time) bool {
+	switch rule.Type {
+	case RuleNewReceiverHighAmount:
+		return !e.knownReceivers[p.Sender][p.Receiver] && p.Amount >= rule.MinAmount
+
+	case RuleRapidRepeats:
+		since := now.Add(-time.Duration(rule.Window))
+		count := 1
+		for _, at := range attempts {
+			if at.After(since) {
+				count++
+			}
+		}
+		return count >= rule.MaxCount
+
+	case RuleUnusualCurrency:
+		for _, currency := range rule.Currencies {
+			if strings.EqualFold(currency, p.Currency) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
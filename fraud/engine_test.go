@@ -0,0 +1,145 @@
+package fraud
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecisionThresholds(t *testing.T) {
+	//one rule per score so each payment lands exactly where we want it
+	config := Config{ReviewScore: 50, DenyScore: 80, Rules: []Rule{
+		{Name: "big", Type: RuleNewReceiverHighAmount, MinAmount: 100, Score: 49},
+		{Name: "odd-currency", Type: RuleUnusualCurrency, Currencies: []string{"INR"}, Score: 1},
+		{Name: "huge", Type: RuleNewReceiverHighAmount, MinAmount: 1000, Score: 30},
+	}}
+
+	cases := []struct {
+		name     string
+		payment  Payment
+		score    int
+		decision string
+	}{
+		{"nothing fired", Payment{Amount: 10, Currency: "INR"}, 0, DecisionAllow},
+		{"just under review", Payment{Amount: 100, Currency: "INR"}, 49, DecisionAllow},
+		{"at review", Payment{Amount: 100, Currency: "USD"}, 50, DecisionReview},
+		{"just under deny", Payment{Amount: 1000, Currency: "INR"}, 79, DecisionReview},
+		{"at deny", Payment{Amount: 1000, Currency: "USD"}, 80, DecisionDeny},
+	}
+
+	for i, c := range cases {
+		//fresh receiver every time so nothing is trusted yet
+		c.payment.Sender = "alice"
+		c.payment.Receiver = string(rune('a' + i))
+		got := NewEngine(config).Evaluate(c.payment, time.Now())
+		if got.Score != c.score || got.Decision != c.decision {
+			t.Errorf("%s: score %d decision %s, want %d %s", c.name, got.Score, got.Decision, c.score, c.decision)
+		}
+	}
+}
+
+func TestDefaultRules(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	now := time.Now()
+
+	got := e.Evaluate(Payment{Sender: "alice", Receiver: "bob", Amount: 999.99, Currency: "INR"}, now)
+	if got.Decision != DecisionAllow || len(got.Matched) != 0 {
+		t.Fatalf("small payment: %+v", got)
+	}
+
+	got = e.Evaluate(Payment{Sender: "alice", Receiver: "carol", Amount: 1000, Currency: "inr"}, now)
+	if got.Decision != DecisionReview || !reflect.DeepEqual(got.Matched, []string{"big-payment-to-new-receiver"}) {
+		t.Fatalf("big payment to new receiver: %+v", got)
+	}
+
+	got = e.Evaluate(Payment{Sender: "alice", Receiver: "dave", Amount: 1000, Currency: "EUR"}, now)
+	if got.Decision != DecisionDeny || got.Score != 80 {
+		t.Fatalf("big payment in odd currency: %+v", got)
+	}
+}
+
+func TestAllowedReceiverIsTrusted(t *testing.T) {
+	e := NewEngine(DefaultConfig())
+	now := time.Now()
+
+	//held payments don't make the receiver known
+	if got := e.Evaluate(Payment{Sender: "alice", Receiver: "bob", Amount: 5000, Currency: "INR"}, now); got.Decision != DecisionReview {
+		t.Fatalf("first big payment: %+v", got)
+	}
+	if got := e.Evaluate(Payment{Sender: "alice", Receiver: "bob", Amount: 5000, Currency: "INR"}, now.Add(time.Hour)); got.Decision != DecisionReview {
+		t.Fatalf("second big payment: %+v", got)
+	}
+
+	e.Trust("alice", "bob")
+	if got := e.Evaluate(Payment{Sender: "alice", Receiver: "bob", Amount: 5000, Currency: "INR"}, now.Add(2*time.Hour)); got.Decision != DecisionAllow {
+		t.Fatalf("after approval: %+v", got)
+	}
+
+	e.Evaluate(Payment{Sender: "alice", Receiver: "carol", Amount: 10, Currency: "INR"}, now)
+	if got := e.Evaluate(Payment{Sender: "alice", Receiver: "carol", Amount: 5000, Currency: "INR"}, now.Add(time.Hour)); got.Decision != DecisionAllow {
+		t.Fatalf("big payment after a small one went through: %+v", got)
+	}
+}
+
+func TestRapidRepeatsWindow(t *testing.T) {
+	e := NewEngine(Config{ReviewScore: 40, DenyScore: 80, Rules: []Rule{
+		{Name: "rapid-repeats", Type: RuleRapidRepeats, MaxCount: 3, Window: Duration(time.Minute), Score: 40},
+	}})
+	now := time.Now()
+	p := Payment{Sender: "alice", Receiver: "bob", Amount: 1, Currency: "INR"}
+
+	e.Evaluate(p, now.Add(-50*time.Second))
+	if got := e.Evaluate(p, now.Add(-10*time.Second)); got.Decision != DecisionAllow {
+		t.Fatalf("second payment: %+v", got)
+	}
+	if got := e.Evaluate(p, now); got.Decision != DecisionReview {
+		t.Fatalf("third payment within a minute: %+v", got)
+	}
+	if got := e.Evaluate(Payment{Sender: "alice", Receiver: "carol", Amount: 1, Currency: "INR"}, now); got.Decision != DecisionAllow {
+		t.Fatalf("other receiver: %+v", got)
+	}
+
+	//only the last two are still inside the window
+	e = NewEngine(e.config)
+	e.Evaluate(p, now.Add(-time.Minute))
+	e.Evaluate(p, now.Add(-30*time.Second))
+	if got := e.Evaluate(p, now); got.Decision != DecisionAllow {
+		t.Fatalf("first payment a full minute ago still counted: %+v", got)
+	}
+}
+
+func TestLoadConfigValidates(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	config, err := LoadConfig(write("good.yaml", "reviewScore: 50\ndenyScore: 80\nrules:\n  - {name: rapid-repeats, type: rapid_repeats, maxCount: 3, window: 1m, score: 40}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Rules) != 1 || time.Duration(config.Rules[0].Window) != time.Minute {
+		t.Fatalf("loaded %+v", config)
+	}
+
+	for name, content := range map[string]string{
+		"no-thresholds.yaml":   "rules: []\n",
+		"review-above.yaml":    "reviewScore: 90\ndenyScore: 80\n",
+		"unknown-type.yaml":    "reviewScore: 50\ndenyScore: 80\nrules:\n  - {name: x, type: moon_phase, score: 10}\n",
+		"no-name.yaml":         "reviewScore: 50\ndenyScore: 80\nrules:\n  - {type: unusual_currency, currencies: [USD], score: 10}\n",
+		"no-window.yaml":       "reviewScore: 50\ndenyScore: 80\nrules:\n  - {name: x, type: rapid_repeats, maxCount: 3, score: 10}\n",
+		"no-currencies.yaml":   "reviewScore: 50\ndenyScore: 80\nrules:\n  - {name: x, type: unusual_currency, score: 10}\n",
+		"bad-duration.yaml":    "reviewScore: 50\ndenyScore: 80\nrules:\n  - {name: x, type: rapid_repeats, maxCount: 3, window: soon, score: 10}\n",
+		"zero-min-amount.yaml": "reviewScore: 50\ndenyScore: 80\nrules:\n  - {name: x, type: new_receiver_high_amount, score: 10}\n",
+	} {
+		if _, err := LoadConfig(write(name, content)); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
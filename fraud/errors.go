@@ -0,0 +1,32 @@
+package fraud
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "fraud.secure-payment-gateway"
+
+// reason sent back when the rules refuse a payment
+const ReasonDenied = "FRAUD_DENIED"
+
+// function to build the error returned for a denied payment, the matched rules go along as metadata
+func (a Assessment) DenyError() error {
+	st := status.New(codes.PermissionDenied, fmt.Sprintf("Payment denied by fraud rules (score %d)", a.Score))
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: ReasonDenied,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"score": fmt.Sprint(a.Score),
+			"rules": strings.Join(a.Matched, ","),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
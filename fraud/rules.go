@@ -0,0 +1,123 @@
+package fraud
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// kinds of rules the engine knows
+const (
+	//first payment to a receiver at or above MinAmount
+	RuleNewReceiverHighAmount = "new_receiver_high_amount"
+	//MaxCount or more payments from one sender to one receiver within Window
+	RuleRapidRepeats = "rapid_repeats"
+	//currency outside of Currencies
+	RuleUnusualCurrency = "unusual_currency"
+)
+
+// yaml and json friendly duration, written as "30s", "5m" ...
+type Duration time.Duration
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	parsed, err := time.ParseDuration(node.Value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// one rule, only the fields of its type are looked at
+type Rule struct {
+	Name       string   `yaml:"name"`
+	Type       string   `yaml:"type"`
+	Score      int      `yaml:"score"`
+	MinAmount  float64  `yaml:"minAmount"`
+	MaxCount   int      `yaml:"maxCount"`
+	Window     Duration `yaml:"window"`
+	Currencies []string `yaml:"currencies"`
+}
+
+// rules and the score thresholds turning a score into a decision
+type Config struct {
+	//score at which a payment is held for manual review
+	ReviewScore int `yaml:"reviewScore"`
+	//score at which a payment is refused outright
+	DenyScore int    `yaml:"denyScore"`
+	Rules     []Rule `yaml:"rules"`
+}
+
+// rules used when no file is given
+func DefaultConfig() Config {
+	return Config{
+		ReviewScore: 50,
+		DenyScore:   80,
+		Rules: []Rule{
+			{Name: "big-payment-to-new-receiver", Type: RuleNewReceiverHighAmount, MinAmount: 1000, Score: 50},
+			{Name: "rapid-repeats", Type: RuleRapidRepeats, MaxCount: 3, Window: Duration(time.Minute), Score: 40},
+			{Name: "unusual-currency", Type: RuleUnusualCurrency, Currencies: []string{"USD", "INR"}, Score: 30},
+		},
+	}
+}
+
+// function to read rules from a yaml or json file (json is valid yaml), e.g.
+//
+//	reviewScore: 50
+//	denyScore: 80
+//	rules:
+//	  - {name: rapid-repeats, type: rapid_repeats, maxCount: 3, window: 1m, score: 40}
+func LoadConfig(path string) (Config, error) {
+	var config Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("Failed to read fraud rules file: %v", err)
+	}
+
+	err = yaml.Unmarshal(data, &config)
+	if err != nil {
+		return config, fmt.Errorf("Failed to parse fraud rules file: %v", err)
+	}
+
+	err = config.validate()
+	if err != nil {
+		return config, fmt.Errorf("Invalid fraud rules file: %v", err)
+	}
+	return config, nil
+}
+
+// function to catch typos in the rules file before they silently never fire
+func (c Config) validate() error {
+	if c.ReviewScore <= 0 || c.DenyScore <= 0 {
+		return fmt.Errorf("reviewScore and denyScore must be positive")
+	}
+	if c.ReviewScore > c.DenyScore {
+		return fmt.Errorf("reviewScore %d is above denyScore %d", c.ReviewScore, c.DenyScore)
+	}
+
+	for i, rule := range c.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has no name", i)
+		}
+		switch rule.Type {
+		case RuleNewReceiverHighAmount:
+			if rule.MinAmount <= 0 {
+				return fmt.Errorf("rule %s needs a positive minAmount", rule.Name)
+			}
+		case RuleRapidRepeats:
+			if rule.MaxCount <= 0 || rule.Window <= 0 {
+				return fmt.Errorf("rule %s needs a positive maxCount and window", rule.Name)
+			}
+		case RuleUnusualCurrency:
+			if len(rule.Currencies) == 0 {
+				return fmt.Errorf("rule %s needs currencies", rule.Name)
+			}
+		default:
+			return fmt.Errorf("rule %s has unknown type %q", rule.Name, rule.Type)
+		}
+	}
+	return nil
+}
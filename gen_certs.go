@@ -0,0 +1,36 @@
+package main
+
+import (
+	authee "assignment_2/auth"
+	"assignment_2/tlsutil"
+	"flag"
+	"fmt"
+	"log"
+)
+
+// generates a local CA and one certificate per service so every hop can run over (m)TLS
+func main() {
+	dir := flag.String("dir", "certs", "directory to write certificates into")
+	flag.Parse()
+
+	services := []string{
+		authee.ServiceAuthServer,
+		authee.ServiceAuthLoadBalancer,
+		authee.ServiceBankServer,
+		authee.ServiceBankLoadBalancer,
+		authee.ServicePaymentGateway,
+		authee.ServiceTwoPhaseCommit,
+		authee.ServiceTransactionLog,
+		authee.ServiceOfflineQueue,
+		authee.ServiceWebhooks,
+		authee.ServiceRestGateway,
+		"client",
+	}
+
+	_, err := tlsutil.GenerateLocalCA(*dir, services...)
+	if err != nil {
+		log.Fatalf("Failed to generate certificates: %v", err)
+	}
+
+	fmt.Printf("Local CA and %d service certificates written to %s\n", len(services), *dir)
+}
@@ -0,0 +1,56 @@
+package limits
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// which limit got hit
+const (
+	RulePerTransaction = "MAX_PER_TRANSACTION"
+	RuleDailyCap       = "DAILY_CAP"
+	RuleMonthlyCap     = "MONTHLY_CAP"
+	RuleVelocity       = "VELOCITY"
+)
+
+const errorDomain = "limits.secure-payment-gateway"
+
+// a debit that would break a limit
+type Breach struct {
+	Rule string
+	//the configured limit, an amount or for velocity a count
+	Limit float64
+	//what the total would have become with this debit
+	Attempted float64
+	//zero for the per transaction limit
+	Window time.Duration
+}
+
+func (b *Breach) Error() string {
+	if b.Window > 0 {
+		return fmt.Sprintf("Limit %s exceeded: %.2f over %v, limit %.2f", b.Rule, b.Attempted, b.Window, b.Limit)
+	}
+	return fmt.Sprintf("Limit %s exceeded: %.2f, limit %.2f", b.Rule, b.Attempted, b.Limit)
+}
+
+// lets grpc send the breach as ResourceExhausted with an ErrorInfo detail
+func (b *Breach) GRPCStatus() *status.Status {
+	st := status.New(codes.ResourceExhausted, b.Error())
+	meta := map[string]string{
+		"limit":     fmt.Sprintf("%.2f", b.Limit),
+		"attempted": fmt.Sprintf("%.2f", b.Attempted),
+	}
+	if b.Window > 0 {
+		meta["window"] = b.Window.String()
+	}
+
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: b.Rule, Domain: errorDomain, Metadata: meta})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
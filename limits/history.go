@@ -0,0 +1,135 @@
+package limits
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// one line of the history file, a debit that went through
+type historyLine struct {
+	Account string    `json:"account"`
+	Amount  float64   `json:"amount"`
+	At      time.Time `json:"at"`
+}
+
+// function to keep the debit history in the file at path, so a restart doesn't reset caps and velocity
+// debits already in it are loaded, the file is rewritten without the ones no window looks at anymore
+func (e *Engine) OpenHistory(path string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	lines, err := readHistory(path)
+	if err != nil {
+		return err
+	}
+
+	//write aside, sync, rename, so a crash leaves either the old file or the new one
+	now := time.Now()
+	temp := path + ".tmp"
+	file, err := os.OpenFile(temp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to rewrite limits history: %v", err)
+	}
+	writer := bufio.NewWriter(file)
+	history := make(map[string][]debit)
+	for _, line := range lines {
+		if now.Sub(line.At) >= maxHistory {
+			continue
+		}
+		data, _ := json.Marshal(line)
+		writer.Write(append(data, '\n'))
+		history[line.Account] = append(history[line.Account], debit{amount: line.Amount, at: line.At})
+	}
+	err = writer.Flush()
+	if err == nil {
+		err = file.Sync()
+	}
+	file.Close()
+	if err == nil {
+		err = os.Rename(temp, path)
+	}
+	if err != nil {
+		os.Remove(temp)
+		return fmt.Errorf("Failed to rewrite limits history: %v", err)
+	}
+
+	e.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to open limits history: %v", err)
+	}
+	e.history = history
+	return nil
+}
+
+// function to read every debit in the history file, a missing file is an empty history
+func readHistory(path string) ([]historyLine, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open limits history: %v", err)
+	}
+	defer file.Close()
+
+	var lines []historyLine
+	reader := bufio.NewReader(file)
+	for number := 1; ; number++ {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			//anything without a newline at the end never finished writing
+			return lines, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read limits history: %v", err)
+		}
+
+		var line historyLine
+		errJson := json.Unmarshal(data, &line)
+		if errJson != nil {
+			return nil, fmt.Errorf("Corrupt limits history at line %d: %v", number, errJson)
+		}
+		lines = append(lines, line)
+	}
+}
+
+// function to add a debit to the history file, caller holds mu
+// a failed write is cut off again, so the next one doesn't get glued onto half a line
+func (e *Engine) writeHistory(line historyLine) error {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+
+	offset, err := e.file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("Failed to write limits history: %v", err)
+	}
+	_, err = e.file.Write(append(data, '\n'))
+	if err == nil {
+		err = e.file.Sync()
+	}
+	if err != nil {
+		e.file.Truncate(offset)
+		return fmt.Errorf("Failed to write limits history: %v", err)
+	}
+	return nil
+}
+
+// function to close the history file, the engine keeps checking from memory
+func (e *Engine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	return err
+}
@@ -0,0 +1,89 @@
+package limits
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openEngine(t *testing.T, path string) *Engine {
+	t.Helper()
+	e := NewEngine(Config{Tiers: map[string]Limits{DefaultTier: {DailyCap: 100}}})
+	if err := e.OpenHistory(path); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { e.Close() })
+	return e
+}
+
+func TestHistorySurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Now()
+
+	e := openEngine(t, path)
+	if err := e.Record("acct", 80, now); err != nil {
+		t.Fatal(err)
+	}
+	e.Close()
+
+	e = openEngine(t, path)
+	var breach *Breach
+	err := e.Check("acct", DefaultTier, 30, now)
+	if !errors.As(err, &breach) || breach.Rule != RuleDailyCap {
+		t.Fatalf("debit over the cap after restart: got %v, want %s breach", err, RuleDailyCap)
+	}
+}
+
+func TestOldDebitsAreDroppedOnOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Now()
+
+	e := openEngine(t, path)
+	e.Record("acct", 80, now.Add(-maxHistory-time.Hour))
+	e.Record("acct", 10, now)
+	e.Close()
+
+	e = openEngine(t, path)
+	if got := len(e.history["acct"]); got != 1 {
+		t.Fatalf("%d debits loaded, want only the recent one", got)
+	}
+	lines, err := readHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 1 {
+		t.Fatalf("history file keeps %d lines after open, want 1", len(lines))
+	}
+}
+
+func TestTornLastLineIsIgnored(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Now()
+
+	e := openEngine(t, path)
+	e.Record("acct", 50, now)
+	e.Close()
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"account":"acct","amo`)
+	file.Close()
+
+	e = openEngine(t, path)
+	if err := e.Record("acct", 20, now); err != nil {
+		t.Fatal(err)
+	}
+	e.Close()
+
+	lines, err := readHistory(path)
+	if err != nil {
+		t.Fatalf("history after a torn line and a new debit: %v", err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("history has %d debits, want 2", len(lines))
+	}
+}
@@ -0,0 +1,199 @@
+package limits
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// rolling windows of the spending caps
+const (
+	dailyWindow   = 24 * time.Hour
+	monthlyWindow = 30 * 24 * time.Hour
+)
+
+// tier whose limits apply to accounts of a tier missing in the config
+const DefaultTier = "default"
+
+// debits older than this are never looked at again
+const maxHistory = monthlyWindow
+
+// json friendly duration, written as "10m", "1h" ...
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// at most MaxCount debits within any Window
+type VelocityRule struct {
+	MaxCount int      `json:"maxCount"`
+	Window   Duration `json:"window"`
+}
+
+// limits of one account or tier, zero means unlimited
+type Limits struct {
+	MaxPerTransaction float64        `json:"maxPerTransaction"`
+	DailyCap          float64        `json:"dailyCap"`
+	MonthlyCap        float64        `json:"monthlyCap"`
+	Velocity          []VelocityRule `json:"velocity"`
+}
+
+// limits per tier, accounts listed separately replace their tier limits completely
+type Config struct {
+	Tiers    map[string]Limits `json:"tiers"`
+	Accounts map[string]Limits `json:"accounts"`
+}
+
+// function to read a config file like
+//
+//	{"tiers": {"default": {"maxPerTransaction": 5000, "dailyCap": 20000, "velocity": [{"maxCount": 5, "window": "1m"}]}},
+//	 "accounts": {"1234567897": {"maxPerTransaction": 100}}}
+func LoadConfig(path string) (Config, error) {
+	var config Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("Failed to read limits file: %v", err)
+	}
+
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return config, fmt.Errorf("Failed to parse limits file: %v", err)
+	}
+	return config, nil
+}
+
+// one debit already applied
+type debit struct {
+	amount float64
+	at     time.Time
+}
+
+// keeps debit history per account and checks new debits against the limits
+type Engine struct {
+	mu      sync.Mutex
+	config  Config
+	history map[string][]debit
+	//where the history survives restarts, nil keeps it in memory only
+	file *os.File
+}
+
+func NewEngine(config Config) *Engine {
+	return &Engine{config: config, history: make(map[string][]debit)}
+}
+
+// function to get limits that apply to an account
+func (e *Engine) limitsFor(accountNumber, tier string) Limits {
+	accountLimits, exists := e.config.Accounts[accountNumber]
+	if exists {
+		return accountLimits
+	}
+	tierLimits, exists := e.config.Tiers[tier]
+	if exists {
+		return tierLimits
+	}
+	return e.config.Tiers[DefaultTier]
+}
+
+// function to drop debits nobody looks at anymore, caller holds mu
+func (e *Engine) prune(accountNumber string, now time.Time) []debit {
+	debits := e.history[accountNumber]
+	keep := 0
+	for keep < len(debits) && now.Sub(debits[keep].at) >= maxHistory {
+		keep++
+	}
+	debits = debits[keep:]
+	e.history[accountNumber] = debits
+	return debits
+}
+
+// function to check a debit against every limit, nil or a *Breach
+func (e *Engine) Check(accountNumber, tier string, amount float64, now time.Time) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	limits := e.limitsFor(accountNumber, tier)
+	debits := e.prune(accountNumber, now)
+
+	if limits.MaxPerTransaction > 0 && amount > limits.MaxPerTransaction {
+		return &Breach{Rule: RulePerTransaction, Limit: limits.MaxPerTransaction, Attempted: amount}
+	}
+
+	if limits.DailyCap > 0 {
+		spent := sumSince(debits, now.Add(-dailyWindow))
+		if spent+amount > limits.DailyCap {
+			return &Breach{Rule: RuleDailyCap, Limit: limits.DailyCap, Attempted: spent + amount, Window: dailyWindow}
+		}
+	}
+
+	if limits.MonthlyCap > 0 {
+		spent := sumSince(debits, now.Add(-monthlyWindow))
+		if spent+amount > limits.MonthlyCap {
+			return &Breach{Rule: RuleMonthlyCap, Limit: limits.MonthlyCap, Attempted: spent + amount, Window: monthlyWindow}
+		}
+	}
+
+	for _, rule := range limits.Velocity {
+		window := time.Duration(rule.Window)
+		if rule.MaxCount <= 0 || window <= 0 {
+			continue
+		}
+		count := countSince(debits, now.Add(-window))
+		if count+1 > rule.MaxCount {
+			return &Breach{Rule: RuleVelocity, Limit: float64(rule.MaxCount), Attempted: float64(count + 1), Window: window}
+		}
+	}
+
+	return nil
+}
+
+// function to remember a debit that went through, written to the history file if there is one
+// the debit counts from memory even when writing it fails
+func (e *Engine) Record(accountNumber string, amount float64, now time.Time) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.history[accountNumber] = append(e.history[accountNumber], debit{amount: amount, at: now})
+	if e.file == nil {
+		return nil
+	}
+	return e.writeHistory(historyLine{Account: accountNumber, Amount: amount, At: now})
+}
+
+func sumSince(debits []debit, since time.Time) float64 {
+	total := 0.0
+	for _, d := range debits {
+		if d.at.After(since) {
+			total += d.amount
+		}
+	}
+	return total
+}
+
+func countSince(debits []debit, since time.Time) int {
+	count := 0
+	for _, d := range debits {
+		if d.at.After(since) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,124 @@
+package limits
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// function to check err is a breach of rule, or nil when rule is empty
+func expectRule(t *testing.T, name string, err error, rule string) {
+	t.Helper()
+	if rule == "" {
+		if err != nil {
+			t.Errorf("%s: refused with %v", name, err)
+		}
+		return
+	}
+	var breach *Breach
+	if !errors.As(err, &breach) || breach.Rule != rule {
+		t.Errorf("%s: got %v, want %s breach", name, err, rule)
+	}
+}
+
+func TestMaxPerTransactionBoundary(t *testing.T) {
+	e := NewEngine(Config{Tiers: map[string]Limits{DefaultTier: {MaxPerTransaction: 100}}})
+	now := time.Now()
+
+	expectRule(t, "at the limit", e.Check("acct", DefaultTier, 100, now), "")
+	expectRule(t, "a cent over", e.Check("acct", DefaultTier, 100.01, now), RulePerTransaction)
+}
+
+func TestDailyCapBoundary(t *testing.T) {
+	e := NewEngine(Config{Tiers: map[string]Limits{DefaultTier: {DailyCap: 100}}})
+	now := time.Now()
+	e.Record("acct", 60, now.Add(-time.Hour))
+
+	expectRule(t, "up to the cap", e.Check("acct", DefaultTier, 40, now), "")
+	expectRule(t, "a cent over", e.Check("acct", DefaultTier, 40.01, now), RuleDailyCap)
+	//a day later the first debit has left the window
+	expectRule(t, "window moved on", e.Check("acct", DefaultTier, 100, now.Add(-time.Hour).Add(dailyWindow)), "")
+	expectRule(t, "other account", e.Check("other", DefaultTier, 100, now), "")
+}
+
+func TestMonthlyCapBoundary(t *testing.T) {
+	e := NewEngine(Config{Tiers: map[string]Limits{DefaultTier: {MonthlyCap: 1000}}})
+	now := time.Now()
+	e.Record("acct", 700, now.Add(-20*24*time.Hour))
+	e.Record("acct", 200, now.Add(-2*24*time.Hour))
+
+	expectRule(t, "up to the cap", e.Check("acct", DefaultTier, 100, now), "")
+	expectRule(t, "a cent over", e.Check("acct", DefaultTier, 100.01, now), RuleMonthlyCap)
+	expectRule(t, "window moved on", e.Check("acct", DefaultTier, 800, now.Add(-20*24*time.Hour).Add(monthlyWindow)), "")
+}
+
+func TestVelocityBoundary(t *testing.T) {
+	rule := VelocityRule{MaxCount: 3, Window: Duration(time.Minute)}
+	e := NewEngine(Config{Tiers: map[string]Limits{DefaultTier: {Velocity: []VelocityRule{rule}}}})
+	now := time.Now()
+	first := now.Add(-50 * time.Second)
+	e.Record("acct", 1, first)
+	e.Record("acct", 1, now.Add(-10*time.Second))
+
+	expectRule(t, "last one allowed", e.Check("acct", DefaultTier, 1, now), "")
+	e.Record("acct", 1, now)
+	expectRule(t, "one too many", e.Check("acct", DefaultTier, 1, now), RuleVelocity)
+	expectRule(t, "oldest left the window", e.Check("acct", DefaultTier, 1, first.Add(time.Minute)), "")
+}
+
+func TestVelocityRulesAllApply(t *testing.T) {
+	e := NewEngine(Config{Tiers: map[string]Limits{DefaultTier: {Velocity: []VelocityRule{
+		{MaxCount: 5, Window: Duration(time.Minute)},
+		{MaxCount: 2, Window: Duration(10 * time.Second)},
+		//broken rules are skipped
+		{MaxCount: 0, Window: Duration(time.Hour)},
+		{MaxCount: 1, Window: 0},
+	}}}})
+	now := time.Now()
+	e.Record("acct", 1, now.Add(-5*time.Second))
+
+	expectRule(t, "second in ten seconds", e.Check("acct", DefaultTier, 1, now), "")
+	e.Record("acct", 1, now)
+	expectRule(t, "third in ten seconds", e.Check("acct", DefaultTier, 1, now), RuleVelocity)
+}
+
+func TestAccountLimitsReplaceTier(t *testing.T) {
+	e := NewEngine(Config{
+		Tiers: map[string]Limits{
+			DefaultTier: {MaxPerTransaction: 100},
+			"gold":      {MaxPerTransaction: 1000},
+		},
+		Accounts: map[string]Limits{"special": {DailyCap: 50}},
+	})
+	now := time.Now()
+
+	expectRule(t, "gold tier", e.Check("acct", "gold", 1000, now), "")
+	expectRule(t, "unknown tier falls back to default", e.Check("acct", "platinum", 101, now), RulePerTransaction)
+	//no per transaction limit for this account, its own daily cap instead
+	expectRule(t, "account under its cap", e.Check("special", "gold", 50, now), "")
+	expectRule(t, "account over its cap", e.Check("special", "gold", 51, now), RuleDailyCap)
+}
+
+func TestZeroMeansUnlimited(t *testing.T) {
+	e := NewEngine(Config{})
+	expectRule(t, "no limits", e.Check("acct", DefaultTier, 1e9, time.Now()), "")
+}
+
+func TestBreachIsResourceExhausted(t *testing.T) {
+	err := error(&Breach{Rule: RuleDailyCap, Limit: 100, Attempted: 120, Window: dailyWindow})
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		t.Fatalf("got %v, want ResourceExhausted", err)
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if ok && info.Reason == RuleDailyCap && info.Domain == errorDomain && info.Metadata["window"] == "24h0m0s" {
+			return
+		}
+	}
+	t.Fatalf("no ErrorInfo for the breach in %v", st.Details())
+}
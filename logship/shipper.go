@@ -0,0 +1,238 @@
+package logship
+
+import (
+	pb "assignment_2/proto"
+	"assignment_2/queuelog"
+	"assignment_2/retry"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// most entries sent in one StreamLogs call
+const BatchSize = 100
+
+// how long the sender waits for more entries before it sends a batch that isn't full
+const FlushDelay = 100 * time.Millisecond
+
+// how long one batch may take before it is given up and sent again
+const sendTimeout = 10 * time.Second
+
+// one entry in the spool, offset is where it sits in the spool log
+type spooled struct {
+	offset uint64
+	entry  *pb.LogEntry
+}
+
+// ships log entries to the LoggingService without dropping any
+//
+// Log writes an entry to a local spool (a queuelog in a directory) and returns, so a
+// logger that is down or a crash of the caller loses nothing. a background sender streams
+// spooled entries in batches over StreamLogs and acks them in the spool only after the
+// logger answered that it wrote all of them. a batch that failed part way is sent again
+// whole and the logger skips entries it already has by their EntryId, so entries are
+// delivered at least once and written once.
+type Shipper struct {
+	spool   *queuelog.Log
+	conn    *grpc.ClientConn
+	client  pb.LoggingServiceClient
+	backoff retry.Backoff
+
+	mu sync.Mutex
+	//spooled and not acked yet, in spool order
+	pending []spooled
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// function to open the spool in dir and start shipping to the logger at address
+// entries left in the spool by an earlier run are sent first
+func Open(dir, address string, options ...grpc.DialOption) (*Shipper, error) {
+	spool, err := queuelog.Open(dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open log spool: %v", err)
+	}
+
+	conn, err := grpc.Dial(address, options...)
+	if err != nil {
+		spool.Close()
+		return nil, fmt.Errorf("Failed to connect to logger: %v", err)
+	}
+
+	s := &Shipper{
+		spool:   spool,
+		conn:    conn,
+		client:  pb.NewLoggingServiceClient(conn),
+		backoff: retry.Backoff{Base: 500 * time.Millisecond, Max: 30 * time.Second},
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	for _, item := range spool.Pending() {
+		entry := &pb.LogEntry{}
+		err := proto.Unmarshal(item.Payload, entry)
+		if err != nil {
+			conn.Close()
+			spool.Close()
+			return nil, fmt.Errorf("Corrupt log entry in spool at offset %d: %v", item.Offset, err)
+		}
+		s.pending = append(s.pending, spooled{offset: item.Offset, entry: entry})
+	}
+
+	go s.run()
+	s.poke()
+	return s, nil
+}
+
+// function to hand an entry over for shipping, it is safe on disk once this returns
+// entries without an EntryId get one here
+func (s *Shipper) Log(entry *pb.LogEntry) error {
+	entry = proto.Clone(entry).(*pb.LogEntry)
+	if entry.EntryId == "" {
+		entry.EntryId = uuid.New().String()
+	}
+	payload, err := proto.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	//held across the append so pending stays in spool order
+	s.mu.Lock()
+	offset, err := s.spool.Append(payload)
+	if err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("Failed to spool log entry: %v", err)
+	}
+	s.pending = append(s.pending, spooled{offset: offset, entry: entry})
+	s.mu.Unlock()
+
+	s.poke()
+	return nil
+}
+
+// function to number the entries not shipped yet
+func (s *Shipper) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pending)
+}
+
+// function to wake the sender up without blocking
+func (s *Shipper) poke() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// function to send batches until Close, a failed batch is tried again after a backoff
+func (s *Shipper) run() {
+	defer close(s.done)
+
+	failures := 0
+	for {
+		batch := s.nextBatch()
+		if batch == nil {
+			return
+		}
+
+		err := s.send(batch)
+		if err != nil {
+			failures++
+			delay := s.backoff.Delay(failures)
+			fmt.Printf("Failed to ship %d log entries, trying again in %v: %v\n", len(batch), delay, err)
+			select {
+			case <-time.After(delay):
+			case <-s.stop:
+				return
+			}
+			continue
+		}
+		failures = 0
+		s.ack(batch)
+	}
+}
+
+// function to wait for entries and take the oldest ones, nil once Close was called
+func (s *Shipper) nextBatch() []spooled {
+	for s.Pending() == 0 {
+		select {
+		case <-s.wake:
+		case <-s.stop:
+			return nil
+		}
+	}
+
+	//entries often come in bursts, a short wait sends them together
+	if s.Pending() < BatchSize {
+		select {
+		case <-time.After(FlushDelay):
+		case <-s.stop:
+			return nil
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	size := min(len(s.pending), BatchSize)
+	return append([]spooled(nil), s.pending[:size]...)
+}
+
+// function to stream one batch, it is only written for sure when this returns nil
+func (s *Shipper) send(batch []spooled) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+
+	stream, err := s.client.StreamLogs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range batch {
+		err = stream.Send(item.entry)
+		if err != nil {
+			//the logger ended the stream, its reason comes with the answer
+			break
+		}
+	}
+
+	ack, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	if int(ack.Received) != len(batch) {
+		return fmt.Errorf("Logger took %d of %d entries", ack.Received, len(batch))
+	}
+	return nil
+}
+
+// function to drop a shipped batch from the spool, it is always the oldest entries
+func (s *Shipper) ack(batch []spooled) {
+	for _, item := range batch {
+		err := s.spool.Ack(item.offset)
+		if err != nil && !errors.Is(err, queuelog.ErrNotFound) {
+			//left in the spool it is shipped again after a restart, the logger skips it then
+			fmt.Printf("Failed to ack log entry %s in spool: %v\n", item.entry.EntryId, err)
+		}
+	}
+
+	s.mu.Lock()
+	s.pending = s.pending[len(batch):]
+	s.mu.Unlock()
+}
+
+// function to stop shipping, entries not shipped yet stay in the spool for the next Open
+func (s *Shipper) Close() error {
+	close(s.stop)
+	<-s.done
+
+	s.conn.Close()
+	return s.spool.Close()
+}
@@ -0,0 +1,205 @@
+package logship
+
+import (
+	pb "assignment_2/proto"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// logger writing each entry once by its EntryId, the way the LoggingService does
+type logger struct {
+	pb.UnimplementedLoggingServiceServer
+
+	mu      sync.Mutex
+	written []*pb.LogEntry
+	ids     map[string]bool
+	streams int
+	//streams to end with an error after writing this many of their entries
+	failAfter []int
+}
+
+func (l *logger) StreamLogs(stream grpc.ClientStreamingServer[pb.LogEntry, pb.LogAck]) error {
+	l.mu.Lock()
+	l.streams++
+	fail := -1
+	if len(l.failAfter) > 0 {
+		fail, l.failAfter = l.failAfter[0], l.failAfter[1:]
+	}
+	l.mu.Unlock()
+
+	ack := &pb.LogAck{}
+	for {
+		entry, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(ack)
+		}
+		if err != nil {
+			return err
+		}
+		if int(ack.Received) == fail {
+			return status.Error(codes.Unavailable, "logger went away")
+		}
+
+		l.mu.Lock()
+		if l.ids[entry.EntryId] {
+			ack.Duplicates++
+		} else {
+			l.ids[entry.EntryId] = true
+			l.written = append(l.written, entry)
+		}
+		l.mu.Unlock()
+		ack.Received++
+	}
+}
+
+func (l *logger) entries() []*pb.LogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]*pb.LogEntry(nil), l.written...)
+}
+
+// function to serve a logger on a local port, returning its address
+func serveLogger(t *testing.T, l *logger) string {
+	t.Helper()
+	l.ids = make(map[string]bool)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterLoggingServiceServer(server, l)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func openShipper(t *testing.T, dir, address string) *Shipper {
+	t.Helper()
+	s, err := Open(dir, address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func logEntries(t *testing.T, s *Shipper, from, to int) {
+	t.Helper()
+	for i := from; i < to; i++ {
+		if err := s.Log(&pb.LogEntry{TransactionId: fmt.Sprintf("tx-%d", i), Status: "Committed"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// function to wait until the logger wrote n entries and fail if they aren't tx-0 .. tx-(n-1) in order
+func waitForEntries(t *testing.T, l *logger, n int) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for len(l.entries()) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("logger wrote %d entries, want %d", len(l.entries()), n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	entries := l.entries()
+	if len(entries) != n {
+		t.Fatalf("logger wrote %d entries, want %d", len(entries), n)
+	}
+	for i, entry := range entries {
+		if entry.TransactionId != fmt.Sprintf("tx-%d", i) {
+			t.Fatalf("entry %d is %s", i, entry.TransactionId)
+		}
+	}
+}
+
+// function to wait until the shipper acked everything it was given
+func waitForAcks(t *testing.T, s *Shipper) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for s.Pending() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d entries still pending", s.Pending())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEntriesAreShippedInOrder(t *testing.T) {
+	l := &logger{}
+	s := openShipper(t, t.TempDir(), serveLogger(t, l))
+	defer s.Close()
+
+	given := &pb.LogEntry{TransactionId: "tx-0", EntryId: "mine"}
+	if err := s.Log(given); err != nil {
+		t.Fatal(err)
+	}
+	logEntries(t, s, 1, BatchSize+20)
+	waitForEntries(t, l, BatchSize+20)
+
+	entries := l.entries()
+	if entries[0].EntryId != "mine" {
+		t.Fatalf("given entry id became %q", entries[0].EntryId)
+	}
+	if entries[1].EntryId == "" {
+		t.Fatal("entry shipped without an id")
+	}
+	if given.EntryId != "mine" {
+		t.Fatal("caller's entry changed")
+	}
+	waitForAcks(t, s)
+}
+
+func TestSpoolKeepsEntriesWhileLoggerIsDown(t *testing.T) {
+	dir := t.TempDir()
+
+	//nothing listens there, the entries only reach the spool
+	down := openShipper(t, dir, "127.0.0.1:1")
+	logEntries(t, down, 0, 5)
+	if down.Pending() != 5 {
+		t.Fatalf("%d pending with the logger down, want 5", down.Pending())
+	}
+	if err := down.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	//the service restarts with the logger back, the spooled entries go first
+	l := &logger{}
+	s := openShipper(t, dir, serveLogger(t, l))
+	logEntries(t, s, 5, 7)
+	waitForEntries(t, l, 7)
+	waitForAcks(t, s)
+	s.Close()
+
+	//acked in the spool, a later run sends nothing again
+	again := openShipper(t, dir, "127.0.0.1:1")
+	defer again.Close()
+	if again.Pending() != 0 {
+		t.Fatalf("%d entries left in the spool after they were shipped", again.Pending())
+	}
+}
+
+func TestFailedBatchIsSentAgainAndWrittenOnce(t *testing.T) {
+	//the first stream dies after 3 entries were written
+	l := &logger{failAfter: []int{3}}
+	s := openShipper(t, t.TempDir(), serveLogger(t, l))
+	defer s.Close()
+
+	logEntries(t, s, 0, 6)
+	waitForEntries(t, l, 6)
+	waitForAcks(t, s)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.streams < 2 {
+		t.Fatalf("batch sent in %d streams, want it sent again", l.streams)
+	}
+}
@@ -0,0 +1,88 @@
+package merchants
+
+import (
+	pb "assignment_2/proto"
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// merchants found are trusted this long, payout accounts never change after creation
+const cacheLifetime = 5 * time.Minute
+
+// finds a merchant by its id
+type Directory interface {
+	Merchant(ctx context.Context, merchantId string) (*pb.MerchantDetails, error)
+}
+
+// one merchant kept by RemoteDirectory
+type cachedMerchant struct {
+	merchant *pb.MerchantDetails
+	expiry   time.Time
+}
+
+// directory asking the auth servers, results are cached for cacheLifetime
+type RemoteDirectory struct {
+	authLoadBalancerAddress string
+	dialOptions             []grpc.DialOption
+	mu                      sync.Mutex
+	//merchant id : merchant
+	cache map[string]cachedMerchant
+}
+
+// function to create a directory, dial options must carry tls and this service's identity
+func NewRemoteDirectory(authLoadBalancerAddress string, dialOptions ...grpc.DialOption) *RemoteDirectory {
+	return &RemoteDirectory{
+		authLoadBalancerAddress: authLoadBalancerAddress,
+		dialOptions:             dialOptions,
+		cache:                   make(map[string]cachedMerchant),
+	}
+}
+
+func (rd *RemoteDirectory) Merchant(ctx context.Context, merchantId string) (*pb.MerchantDetails, error) {
+	rd.mu.Lock()
+	cached, found := rd.cache[merchantId]
+	rd.mu.Unlock()
+	if found && time.Now().Before(cached.expiry) {
+		return cached.merchant, nil
+	}
+
+	merchant, err := rd.askAuthServer(ctx, merchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	rd.mu.Lock()
+	rd.cache[merchantId] = cachedMerchant{merchant: merchant, expiry: time.Now().Add(cacheLifetime)}
+	rd.mu.Unlock()
+	return merchant, nil
+}
+
+// function to look the merchant up on whichever auth server the load balancer hands out
+func (rd *RemoteDirectory) askAuthServer(ctx context.Context, merchantId string) (*pb.MerchantDetails, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	lbConn, err := grpc.Dial(rd.authLoadBalancerAddress, rd.dialOptions...)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Failed to connect to auth load balancer: %v", err)
+	}
+	defer lbConn.Close()
+
+	server, err := pb.NewAuthLoadBalancerClient(lbConn).GetAuthServer(ctx, &pb.Empty{})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "No auth server available: %v", err)
+	}
+
+	authConn, err := grpc.Dial(server.Address, rd.dialOptions...)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Failed to connect to auth server: %v", err)
+	}
+	defer authConn.Close()
+
+	return pb.NewAuthenticationClient(authConn).GetMerchant(ctx, &pb.MerchantId{MerchantId: merchantId})
+}
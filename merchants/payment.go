@@ -0,0 +1,37 @@
+package merchants
+
+import (
+	pb "assignment_2/proto"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// function to route a payment naming a merchant into the merchant's payout account
+// the merchant id is the only thing the payer picks, so nobody can move fees or webhooks elsewhere
+// payments without a merchant are left alone
+func Route(ctx context.Context, directory Directory, req *pb.TransactionRequest) error {
+	if req.MerchantId == "" {
+		return nil
+	}
+
+	merchant, err := directory.Merchant(ctx, req.MerchantId)
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.InvalidArgument, "Merchant %s not found", req.MerchantId)
+	}
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "Can't look up merchant %s right now: %v", req.MerchantId, err)
+	}
+
+	if req.RecieverId != "" && req.RecieverId != merchant.PayoutAccount {
+		return status.Errorf(codes.InvalidArgument, "Merchant %s is only paid into its payout account", req.MerchantId)
+	}
+	if req.SenderId == merchant.PayoutAccount {
+		return status.Errorf(codes.InvalidArgument, "Merchant %s can't pay itself", req.MerchantId)
+	}
+
+	req.RecieverId = merchant.PayoutAccount
+	req.MerchantId = merchant.MerchantId
+	return nil
+}
@@ -0,0 +1,82 @@
+package merchants
+
+import (
+	"assignment_2/fees"
+	pb "assignment_2/proto"
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// directory backed by a map, like the auth server's merchants
+type mapDirectory map[string]*pb.MerchantDetails
+
+func (d mapDirectory) Merchant(ctx context.Context, merchantId string) (*pb.MerchantDetails, error) {
+	merchant, exists := d[merchantId]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "Merchant %s not found", merchantId)
+	}
+	return merchant, nil
+}
+
+const (
+	customerAccount = "1000000009"
+	payoutAccount   = "2000000008"
+	platformAccount = "3000000007"
+)
+
+var shop = mapDirectory{"m_shop": {MerchantId: "m_shop", Name: "Shop", PayoutAccount: payoutAccount}}
+
+func TestCustomerPaymentPaysPlatformFee(t *testing.T) {
+	req := &pb.TransactionRequest{SenderId: customerAccount, Amount: 200, MerchantId: "m_shop"}
+	if err := Route(context.Background(), shop, req); err != nil {
+		t.Fatal(err)
+	}
+	if req.RecieverId != payoutAccount || req.MerchantId != "m_shop" {
+		t.Fatalf("routed to %s for %q, want payout account for m_shop", req.RecieverId, req.MerchantId)
+	}
+
+	schedule := fees.Config{PlatformAccount: platformAccount, Default: fees.Schedule{Percentage: 2.5, Fixed: 1}}
+	credits, err := schedule.Credits(req.RecieverId, req.MerchantId, req.Amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]float64{}
+	for _, c := range credits {
+		got[c.Account] += c.Amount
+	}
+	if got[platformAccount] != 6 || got[payoutAccount] != 194 || len(got) != 2 {
+		t.Fatalf("credits %v, want 6 to the platform and 194 to the merchant", got)
+	}
+}
+
+func TestPaymentWithoutMerchantIsLeftAlone(t *testing.T) {
+	req := &pb.TransactionRequest{SenderId: customerAccount, RecieverId: payoutAccount, Amount: 200}
+	if err := Route(context.Background(), shop, req); err != nil {
+		t.Fatal(err)
+	}
+	if req.RecieverId != payoutAccount || req.MerchantId != "" {
+		t.Fatalf("plain payment changed to %s for %q", req.RecieverId, req.MerchantId)
+	}
+}
+
+func TestRouteRejects(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *pb.TransactionRequest
+	}{
+		{"unknown merchant", &pb.TransactionRequest{SenderId: customerAccount, MerchantId: "m_gone"}},
+		{"other receiver", &pb.TransactionRequest{SenderId: customerAccount, RecieverId: platformAccount, MerchantId: "m_shop"}},
+		{"merchant paying itself", &pb.TransactionRequest{SenderId: payoutAccount, MerchantId: "m_shop"}},
+	}
+
+	for _, c := range cases {
+		err := Route(context.Background(), shop, c.req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: got %v, want InvalidArgument", c.name, err)
+		}
+	}
+}
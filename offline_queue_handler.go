@@ -1,152 +1,617 @@
 package main
 
 import (
+	authee "assignment_2/auth"
 	pb "assignment_2/proto"
+	"assignment_2/queuelog"
+	"assignment_2/retry"
+	"assignment_2/tlsutil"
 	"context"
 	"fmt"
 	"log"
 	"net"
+	"os"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type OfflineQueueHandler struct {
 	pb.UnimplementedOfflineQueueServiceServer
-	queue []pb.TransactionRequest
-	mu    sync.Mutex
+	//every queued payment is in the log first, queue is its in-memory view by log offset
+	log           *queuelog.Log
+	queue         map[uint64]*queuedPayment
+	byTransaction map[string]uint64
+	//offsets of each sender's payments in the order they came in, only the first one is ever tried
+	lanes map[string][]uint64
+	//decides when each payment is tried next, attempts run on its workers
+	retries *retry.Scheduler
+	//failed attempts before a payment is moved to the dead letters
+	maxAttempts int
+	mu          sync.Mutex
 }
 
-var bankLoadBalancerAddress = "localhost:50056"
+// states of a payment in the queue
+const (
+	stateQueued = "queued"
+	stateDead   = "dead"
+)
+
+// attempts kept per payment, older ones are dropped from the history
+const maxAttemptHistory = 20
+
+// priority classes, lower goes first when more payments are due than workers are free
+var priorities = map[string]int{
+	"urgent": 0,
+	"normal": 1,
+	"bulk":   2,
+}
+
+const defaultPriority = "normal"
+
+func priorityOf(trans *pb.TransactionRequest) int {
+	priority, ok := priorities[trans.Priority]
+	if !ok {
+		return priorities[defaultPriority]
+	}
+	return priority
+}
+
+// one payment waiting for a retry, offset is where it sits in the log
+type queuedPayment struct {
+	offset  uint64
+	payment *pb.QueuedPayment
+	//an attempt is talking to the bank right now, operators have to wait for it
+	running bool
+}
+
+var paymentGatewayAddress = "localhost:50052"
+
+// statuses the gateway answers a replay with, Queued means it failed for a passing reason
+const (
+	gatewayCommitted     = "Committed"
+	gatewayHeldForReview = "Held for Review"
+	gatewayDenied        = "Denied"
+	gatewayRejected      = "Rejected"
+	gatewayAborted       = "Aborted"
+	//never confirmed by its payer, the gateway only replays queued payments
+	gatewayPending = "Pending"
+	gatewayQueued  = "Queued"
+)
+
+// every call to other services goes over tls and carries our service token
+var transportSecurity = grpc.WithTransportCredentials(tlsutil.ClientCredentials(authee.ServiceOfflineQueue))
+var serviceIdentity = grpc.WithPerRPCCredentials(authee.NewServiceCredentials(authee.ServiceOfflineQueue))
 
 // ProcessQueuedPayments stores failed transactions in a queue for offline processing.
+// it only waits for the log write, retries run on their own workers
 func (oqh *OfflineQueueHandler) ProcessQueuedPayments(ctx context.Context, req *pb.OfflineRequest) (*pb.Response, error) {
 	oqh.mu.Lock()
 	defer oqh.mu.Unlock()
 
-	// Add the failed transactions to the queue.
+	// Add the failed transactions to the queue, written to the log before we answer.
+	count := 0
 	for _, trans := range req.Transactions {
-		oqh.queue = append(oqh.queue, *trans)
+		//operators find payments by id, so every one needs one
+		if trans.TransactionId == "" {
+			trans.TransactionId = uuid.New().String()
+		}
+		//a client retrying the same call must not queue a payment twice
+		_, queued := oqh.byTransaction[trans.TransactionId]
+		if queued {
+			continue
+		}
+		if trans.Priority == "" {
+			trans.Priority = defaultPriority
+		}
+		if _, known := priorities[trans.Priority]; !known {
+			return nil, status.Errorf(codes.InvalidArgument, "Queued %d of %d transactions: unknown priority %q of %s, use urgent, normal or bulk", count, len(req.Transactions), trans.Priority, trans.TransactionId)
+		}
+
+		payment := &pb.QueuedPayment{
+			Transaction: trans,
+			State:       stateQueued,
+			QueuedAt:    time.Now().Format(time.RFC3339),
+		}
+		payload, err := proto.Marshal(payment)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Failed to encode transaction %s: %v", trans.TransactionId, err)
+		}
+		offset, err := oqh.log.Append(payload)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "Queued %d of %d transactions: %v", count, len(req.Transactions), err)
+		}
+
+		item := &queuedPayment{offset: offset, payment: payment}
+		oqh.queue[offset] = item
+		oqh.byTransaction[trans.TransactionId] = offset
+		oqh.lanes[trans.SenderId] = append(oqh.lanes[trans.SenderId], offset)
+		oqh.scheduleHead(trans.SenderId)
+		count++
 	}
 
-	fmt.Printf("Queued %d transactions for offline processing.\n", len(req.Transactions))
+	fmt.Printf("Queued %d transactions for offline processing.\n", count)
 	return &pb.Response{Status: "Transactions queued for offline processing"}, nil
 }
 
-// retryFailedTransations periodically retries processing transactions in the queue.
-func (oqh *OfflineQueueHandler) retryFailedTransations() {
+// function to open the queue log and load what was still queued when we stopped
+func loadOfflineQueue() (*OfflineQueueHandler, error) {
+	dir := os.Getenv("OFFLINE_QUEUE_DIR")
+	if dir == "" {
+		dir = "offline_queue"
+	}
 
-	maxRetries := 5
+	queueLog, err := queuelog.Open(dir, 0)
+	if err != nil {
+		return nil, err
+	}
 
-	for {
-		time.Sleep(10 * time.Second)
+	maxAttempts := 10
+	if value := os.Getenv("OFFLINE_QUEUE_MAX_ATTEMPTS"); value != "" {
+		maxAttempts, err = strconv.Atoi(value)
+		if err != nil || maxAttempts < 1 {
+			return nil, fmt.Errorf("Invalid OFFLINE_QUEUE_MAX_ATTEMPTS %q", value)
+		}
+	}
 
-		oqh.mu.Lock()
-		if len(oqh.queue) == 0 {
-			oqh.mu.Unlock()
-			continue
+	oqh := &OfflineQueueHandler{
+		log:           queueLog,
+		queue:         make(map[uint64]*queuedPayment),
+		byTransaction: make(map[string]uint64),
+		lanes:         make(map[string][]uint64),
+		maxAttempts:   maxAttempts,
+	}
+	dead := 0
+	for _, entry := range queueLog.Pending() {
+		payment, err := decodeQueuedPayment(entry.Payload)
+		if err != nil {
+			queueLog.Close()
+			return nil, fmt.Errorf("Corrupt queued payment at offset %d: %v", entry.Offset, err)
+		}
+		oqh.queue[entry.Offset] = &queuedPayment{offset: entry.Offset, payment: payment}
+		oqh.byTransaction[payment.Transaction.TransactionId] = entry.Offset
+		//pending entries come in offset order, so lanes keep the order payments came in
+		sender := payment.Transaction.SenderId
+		oqh.lanes[sender] = append(oqh.lanes[sender], entry.Offset)
+		if payment.State == stateDead {
+			dead++
+		}
+	}
+
+	fmt.Printf("Recovered %d queued transactions (%d dead) from %s\n", len(oqh.queue), dead, dir)
+	return oqh, nil
+}
+
+// function to read a payment back from the log
+// logs written before retry state was kept hold a bare TransactionRequest
+func decodeQueuedPayment(payload []byte) (*pb.QueuedPayment, error) {
+	payment := &pb.QueuedPayment{}
+	err := proto.Unmarshal(payload, payment)
+	if err == nil && payment.Transaction != nil {
+		return payment, nil
+	}
+
+	trans := &pb.TransactionRequest{}
+	err = proto.Unmarshal(payload, trans)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.QueuedPayment{Transaction: trans, State: stateQueued}, nil
+}
+
+// function to write the current state of a payment to the log, caller holds mu
+func (oqh *OfflineQueueHandler) save(item *queuedPayment) error {
+	payload, err := proto.Marshal(item.payment)
+	if err != nil {
+		return err
+	}
+	return oqh.log.Update(item.offset, payload)
+}
+
+// function to drop a payment from the queue for good, the next one of its sender gets its turn
+// caller holds mu
+func (oqh *OfflineQueueHandler) remove(item *queuedPayment) error {
+	err := oqh.log.Ack(item.offset)
+	if err != nil {
+		return err
+	}
+	delete(oqh.queue, item.offset)
+	delete(oqh.byTransaction, item.payment.Transaction.TransactionId)
+
+	sender := item.payment.Transaction.SenderId
+	lane := oqh.lanes[sender]
+	for i, offset := range lane {
+		if offset == item.offset {
+			lane = append(lane[:i:i], lane[i+1:]...)
+			break
+		}
+	}
+	if len(lane) == 0 {
+		delete(oqh.lanes, sender)
+		return nil
+	}
+	oqh.lanes[sender] = lane
+	oqh.scheduleHead(sender)
+	return nil
+}
+
+// function to get the payment a sender's lane is at, caller holds mu
+func (oqh *OfflineQueueHandler) head(sender string) *queuedPayment {
+	lane := oqh.lanes[sender]
+	if len(lane) == 0 {
+		return nil
+	}
+	return oqh.queue[lane[0]]
+}
+
+// function to start attempts on the first payment of a sender
+// a dead first payment stops the whole lane until an operator requeues or discards it,
+// so a later payment never spends money an earlier one was meant to get. caller holds mu
+func (oqh *OfflineQueueHandler) scheduleHead(sender string) {
+	item := oqh.head(sender)
+	if item == nil || item.running || item.payment.State != stateQueued {
+		return
+	}
+	oqh.retries.Schedule(item.offset, time.Now(), priorityOf(item.payment.Transaction))
+}
+
+// function to copy a payment for a response, with the payment it waits behind, caller holds mu
+func (oqh *OfflineQueueHandler) describe(item *queuedPayment) *pb.QueuedPayment {
+	payment := proto.Clone(item.payment).(*pb.QueuedPayment)
+	head := oqh.head(item.payment.Transaction.SenderId)
+	if head != nil && head != item {
+		payment.WaitingFor = head.payment.Transaction.TransactionId
+	}
+	return payment
+}
+
+// function to read the retry settings from env
+//
+//	OFFLINE_QUEUE_WORKERS  attempts running at once (default 4)
+//	OFFLINE_RETRY_BASE     wait after the first failure, doubled after each one (default 1s)
+//	OFFLINE_RETRY_MAX      longest wait between two attempts (default 5m)
+func retrySettings() (int, retry.Backoff) {
+	workers := 4
+	backoff := retry.DefaultBackoff()
+
+	if value := os.Getenv("OFFLINE_QUEUE_WORKERS"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			log.Fatalf("Invalid OFFLINE_QUEUE_WORKERS %q", value)
 		}
+		workers = n
+	}
+	if value := os.Getenv("OFFLINE_RETRY_BASE"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid OFFLINE_RETRY_BASE %q: %v", value, err)
+		}
+		backoff.Base = d
+	}
+	if value := os.Getenv("OFFLINE_RETRY_MAX"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid OFFLINE_RETRY_MAX %q: %v", value, err)
+		}
+		backoff.Max = d
+	}
+	return workers, backoff
+}
 
-		// process each queued transaction
-		newQueue := []pb.TransactionRequest{}
-		for _, trans := range oqh.queue {
-			currRetries := 0
-			currRetryDelay := 1 * time.Second
-			success := false
-
-			//exponential backoff retries
-			for currRetries < maxRetries {
-				bankServer, err := getAvailableBankServer()
-				if err != nil {
-					fmt.Println("No Available bank server sir currently! Retrying...")
-				} else {
-					//agar ho gya success
-					if processTransaction(bankServer, trans) {
-						success = true
-						break
-					}
-				}
-				currRetries++
-				fmt.Printf("Attempt No. %d failed for %s, waiting %v...", currRetries, trans.TransactionId, currRetryDelay)
-				time.Sleep(currRetryDelay)
-				//exponential increasing delay here
-				currRetryDelay *= 2
-			}
-
-			if !success {
-				//keep in new queue it is not successful
-				newQueue = append(newQueue, trans)
-				fmt.Printf("Failed Transaction %s after %d retries", &trans.TransactionId, maxRetries)
-			}
-		}
-
-		// updating the queue
-		oqh.queue = newQueue
+// function to start the retry workers and schedule everything recovered from the log
+func (oqh *OfflineQueueHandler) startRetries(workers int, backoff retry.Backoff) {
+	oqh.mu.Lock()
+	defer oqh.mu.Unlock()
+
+	oqh.retries = retry.NewScheduler(workers, backoff, oqh.retryPayment)
+	for sender := range oqh.lanes {
+		oqh.scheduleHead(sender)
+	}
+}
+
+// function run by a retry worker for one attempt, returns true once the payment needs no more attempts
+func (oqh *OfflineQueueHandler) retryPayment(offset uint64, attempt int) bool {
+	oqh.mu.Lock()
+	item, exists := oqh.queue[offset]
+	//only the first payment of a sender runs, the others are scheduled when their turn comes
+	if !exists || item.payment.State != stateQueued || item.running || oqh.head(item.payment.Transaction.SenderId) != item {
 		oqh.mu.Unlock()
+		return true
+	}
+	item.running = true
+	trans := item.payment.Transaction
+	oqh.mu.Unlock()
+
+	//agar ho gya success
+	terminal, err := replayTransaction(trans)
+
+	dead := oqh.recordAttempt(item, terminal, err)
+	if dead && err != nil {
+		//a committed replay already left it committed at the gateway, a dead one is still queued there
+		settleAtGateway(trans.TransactionId, gatewayAborted)
+	}
+	return dead
+}
+
+// function to ack a replayed payment or note its failed attempt
+// returns true once the payment needs no more attempts, on success or when it moved to the dead letters
+func (oqh *OfflineQueueHandler) recordAttempt(item *queuedPayment, terminal bool, err error) bool {
+	oqh.mu.Lock()
+	defer oqh.mu.Unlock()
+	item.running = false
+	trans := item.payment.Transaction
+
+	if err == nil {
+		//acked only after the bank took it, a crash in between retries it once more
+		errAck := oqh.remove(item)
+		if errAck != nil {
+			fmt.Printf("Failed to ack transaction %s: %v\n", trans.TransactionId, errAck)
+		}
+		return true
+	}
+
+	payment := item.payment
+	number := int32(1)
+	if len(payment.Attempts) > 0 {
+		number = payment.Attempts[len(payment.Attempts)-1].Number + 1
+	}
+	payment.Attempts = append(payment.Attempts, &pb.QueueAttempt{
+		Number: number,
+		At:     time.Now().Format(time.RFC3339),
+		Error:  err.Error(),
+	})
+	if len(payment.Attempts) > maxAttemptHistory {
+		payment.Attempts = payment.Attempts[len(payment.Attempts)-maxAttemptHistory:]
+	}
+	payment.Failures++
+	payment.LastError = err.Error()
+
+	//no number of retries fixes a payment the gateway turned down for good
+	dead := terminal || int(payment.Failures) >= oqh.maxAttempts
+	if dead {
+		payment.State = stateDead
+		payment.DeadAt = time.Now().Format(time.RFC3339)
+		fmt.Printf("Transaction %s moved to dead letters after %d failed attempts: %v\n", trans.TransactionId, payment.Failures, err)
+	} else {
+		fmt.Printf("Attempt No. %d failed for %s, trying again later: %v\n", number, trans.TransactionId, err)
+	}
+
+	errSave := oqh.save(item)
+	if errSave != nil {
+		fmt.Printf("Failed to save retry state of %s: %v\n", trans.TransactionId, errSave)
+	}
+	return dead
+}
+
+// function to find a payment by transaction id, caller holds mu
+func (oqh *OfflineQueueHandler) find(transactionId string) (*queuedPayment, error) {
+	offset, exists := oqh.byTransaction[transactionId]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "Transaction %s is not in the offline queue", transactionId)
+	}
+	return oqh.queue[offset], nil
+}
+
+// function to name the operator in the logs
+func operatorOf(ctx context.Context) string {
+	caller, ok := authee.CallerFromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+	return caller.Username
+}
+
+// function to list queued and dead payments, oldest first
+func (oqh *OfflineQueueHandler) ListQueuedPayments(ctx context.Context, req *pb.QueueFilter) (*pb.QueuedPaymentList, error) {
+	if req.State != "" && req.State != stateQueued && req.State != stateDead {
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown queue state %q, use %s or %s", req.State, stateQueued, stateDead)
 	}
+
+	oqh.mu.Lock()
+	defer oqh.mu.Unlock()
+
+	offsets := make([]uint64, 0, len(oqh.queue))
+	for offset, item := range oqh.queue {
+		if req.State == "" || item.payment.State == req.State {
+			offsets = append(offsets, offset)
+		}
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+
+	list := &pb.QueuedPaymentList{}
+	for _, offset := range offsets {
+		list.Payments = append(list.Payments, oqh.describe(oqh.queue[offset]))
+	}
+	return list, nil
 }
 
-// getAvailableBankServer returns a connected BankServerClient from the bank load balancer.
-func getAvailableBankServer() (pb.BankServerClient, error) {
-	conn, err := grpc.Dial(bankLoadBalancerAddress, grpc.WithInsecure())
+// function to inspect one payment with its attempt history
+func (oqh *OfflineQueueHandler) GetQueuedPayment(ctx context.Context, req *pb.TransactionID) (*pb.QueuedPayment, error) {
+	oqh.mu.Lock()
+	defer oqh.mu.Unlock()
+
+	item, err := oqh.find(req.TransactionId)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to connect to bank load balancer: %v", err)
+		return nil, err
 	}
-	defer conn.Close()
+	return oqh.describe(item), nil
+}
+
+// function to give a payment a fresh attempt budget and try it right away, the history is kept
+// the gateway only replays queued payments, so a dead one is made queued there first
+func (oqh *OfflineQueueHandler) RequeuePayment(ctx context.Context, req *pb.QueueAction) (*pb.QueuedPayment, error) {
+	oqh.mu.Lock()
+	item, err := oqh.find(req.TransactionId)
+	if err == nil && item.payment.State == stateDead {
+		oqh.mu.Unlock()
+		err = requeueAtGateway(req.TransactionId)
+		if err != nil {
+			return nil, err
+		}
+		oqh.mu.Lock()
+		item, err = oqh.find(req.TransactionId)
+	}
+	defer oqh.mu.Unlock()
 
-	client := pb.NewBankLoadBalancerClient(conn)
-	bankServer, err := client.GetBankServer(context.Background(), &pb.Empty{})
 	if err != nil {
-		return nil, fmt.Errorf("Failed to connect with bank server")
+		return nil, err
+	}
+	if item.running {
+		return nil, status.Errorf(codes.FailedPrecondition, "Transaction %s is being retried right now, try again shortly", req.TransactionId)
 	}
 
-	// Connect to the selected bank server.
-	bankConn, err := grpc.Dial(bankServer.Address, grpc.WithInsecure())
+	item.payment.State = stateQueued
+	item.payment.Failures = 0
+	item.payment.DeadAt = ""
+	err = oqh.save(item)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to connect with bank server: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "Failed to requeue transaction %s: %v", req.TransactionId, err)
 	}
+	//tried right away if it is first in its lane, else when its turn comes
+	oqh.scheduleHead(item.payment.Transaction.SenderId)
 
-	return pb.NewBankServerClient(bankConn), nil
+	fmt.Printf("Transaction %s requeued by %s: %s\n", req.TransactionId, operatorOf(ctx), req.Note)
+	return oqh.describe(item), nil
 }
 
-// processTransaction attempts to deduct money via the bank server.
-func processTransaction(bankClient pb.BankServerClient, trans pb.TransactionRequest) bool {
+// function to drop a payment without another attempt, the gateway then has it as aborted
+func (oqh *OfflineQueueHandler) DiscardPayment(ctx context.Context, req *pb.QueueAction) (*pb.Response, error) {
+	oqh.mu.Lock()
+
+	item, err := oqh.find(req.TransactionId)
+	if err != nil {
+		oqh.mu.Unlock()
+		return nil, err
+	}
+	if item.running {
+		oqh.mu.Unlock()
+		return nil, status.Errorf(codes.FailedPrecondition, "Transaction %s is being retried right now, try again shortly", req.TransactionId)
+	}
+
+	err = oqh.remove(item)
+	oqh.mu.Unlock()
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Failed to discard transaction %s: %v", req.TransactionId, err)
+	}
+
+	fmt.Printf("Transaction %s discarded by %s: %s\n", req.TransactionId, operatorOf(ctx), req.Note)
+	settleAtGateway(req.TransactionId, gatewayAborted)
+	return &pb.Response{Status: "Transaction discarded"}, nil
+}
+
+// function to tell the gateway how a queued payment ended when no replay said so
+func tellGateway(transactionId, finalStatus string) (*pb.TransactionStatus, error) {
+	conn, err := grpc.Dial(paymentGatewayAddress, transportSecurity, serviceIdentity)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	resp, err := bankClient.DeductMoney(ctx, &pb.DeductRequest{
-		AccountNumber: trans.SenderId,
-		Amount:        trans.Amount,
-	})
+	return pb.NewPaymentGatewayClient(conn).SettleQueuedTransaction(ctx, &pb.TransactionStatus{TransactionId: transactionId, Status: finalStatus})
+}
+
+// function to mark a payment the queue gave up on as aborted at the gateway, a failure only leaves it showing queued
+func settleAtGateway(transactionId, finalStatus string) {
+	resp, err := tellGateway(transactionId, finalStatus)
+	if err != nil {
+		fmt.Printf("Failed to tell the gateway %s is %s: %v\n", transactionId, finalStatus, err)
+		return
+	}
+	if resp.Status != finalStatus {
+		fmt.Printf("Gateway keeps %s as %s\n", transactionId, resp.Status)
+	}
+}
 
+// function to make a dead payment queued again at the gateway before it is retried
+func requeueAtGateway(transactionId string) error {
+	resp, err := tellGateway(transactionId, gatewayQueued)
+	if status.Code(err) == codes.NotFound {
+		//lost in a gateway restart, the replay brings it back as queued
+		return nil
+	}
 	if err != nil {
-		fmt.Printf("Transaction failed: %v\n", err)
-		return false
+		return status.Errorf(codes.Unavailable, "Failed to requeue transaction %s at the gateway: %v", transactionId, err)
 	}
-	if !resp.Success {
-		fmt.Printf("Transaction failed: response unsuccessful\n")
-		return false
+	if resp.Status != gatewayQueued {
+		return status.Errorf(codes.FailedPrecondition, "Transaction %s is %s at the gateway, it can't be retried", transactionId, resp.Status)
 	}
+	return nil
+}
 
-	fmt.Printf("Transaction succeeded! : %s\n", trans.TransactionId)
-	return true
+// function to run a payment through the gateway's 2PC path again, under its original id
+// terminal is true when retrying can't help, e.g. the payment was denied or is invalid
+func replayTransaction(trans *pb.TransactionRequest) (bool, error) {
+	conn, err := grpc.Dial(paymentGatewayAddress, transportSecurity, serviceIdentity)
+	if err != nil {
+		return false, fmt.Errorf("Failed to connect to payment gateway: %v", err)
+	}
+	defer conn.Close()
+
+	//2PC may take a while, the gateway waits up to 15 seconds on the coordinator
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	resp, err := pb.NewPaymentGatewayClient(conn).ReplayTransaction(ctx, trans)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.PermissionDenied, codes.NotFound:
+			return true, fmt.Errorf("Transaction refused: %v", status.Convert(err).Message())
+		}
+		return false, fmt.Errorf("Transaction failed: %v", status.Convert(err).Message())
+	}
+
+	switch resp.Status {
+	case gatewayCommitted:
+		fmt.Printf("Transaction succeeded! : %s\n", trans.TransactionId)
+		return false, nil
+	case gatewayHeldForReview:
+		//an operator decides now, the queue is done with it
+		fmt.Printf("Transaction %s held for review by the gateway\n", trans.TransactionId)
+		return false, nil
+	case gatewayDenied, gatewayRejected, gatewayAborted:
+		//aborted only for failures that won't go away, e.g. insufficient funds
+		return true, fmt.Errorf("Transaction %s", resp.Status)
+	case gatewayPending:
+		return true, fmt.Errorf("Transaction %s is waiting for its payer to confirm it", trans.TransactionId)
+	}
+	return false, fmt.Errorf("Transaction not committed: %s", resp.Status)
 }
 
 func main() {
+	//service tokens can't be signed or checked without the shared secret
+	if err := authee.LoadServiceSecret(); err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	listen, err := net.Listen("tcp", ":50059")
 	if err != nil {
 		log.Fatalf("Failed to listen at this port: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	offlineHandle := &OfflineQueueHandler{}
+	grpcServer := grpc.NewServer(grpc.Creds(tlsutil.ServerCredentials(authee.ServiceOfflineQueue)), grpc.UnaryInterceptor(authee.AuthInterceptor), grpc.StreamInterceptor(authee.StreamAuthInterceptor))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	offlineHandle, err := loadOfflineQueue()
+	if err != nil {
+		log.Fatalf("Failed to load offline queue: %v", err)
+	}
 
 	// Register the new OfflineQueueService.
 	pb.RegisterOfflineQueueServiceServer(grpcServer, offlineHandle)
 
 	// Start background retry processing.
-	go offlineHandle.retryFailedTransations()
+	offlineHandle.startRetries(retrySettings())
 
 	fmt.Println("Offline queue handler running on port 50059")
 	if err := grpcServer.Serve(listen); err != nil {
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"assignment_2/restapi"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+// checks restapi/openapi.yaml against the rest routes and Stripe.proto
+//
+//	go run openapi_check.go                    check the embedded spec
+//	go run openapi_check.go -spec other.yaml   check another file
+func main() {
+	specFile := flag.String("spec", "", "openapi file to check instead of the embedded one")
+	flag.Parse()
+
+	spec := restapi.OpenAPISpec
+	if *specFile != "" {
+		data, err := os.ReadFile(*specFile)
+		if err != nil {
+			log.Fatalf("Failed to read spec: %v", err)
+		}
+		spec = data
+	}
+
+	problems := restapi.CheckSpec(spec, restapi.Routes())
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		log.Fatalf("openapi spec has %d problems", len(problems))
+	}
+	fmt.Printf("openapi spec matches %d routes\n", len(restapi.Routes()))
+}
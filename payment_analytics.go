@@ -0,0 +1,151 @@
+package main
+
+import (
+	"assignment_2/eventbus"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// running totals over every payment event, saved with the offsets they cover
+type Totals struct {
+	//events seen by type
+	Counts          map[string]int64 `json:"counts"`
+	CommittedVolume float64          `json:"committedVolume"`
+	RefundedVolume  float64          `json:"refundedVolume"`
+	//offset past the last event counted per partition, an event handed over again is below it
+	Next map[int]int64 `json:"next"`
+}
+
+// how often the totals are printed, when they changed
+const reportInterval = 30 * time.Second
+
+var totals = Totals{Counts: make(map[string]int64), Next: make(map[int]int64)}
+var totalsMut sync.Mutex
+var changed bool
+
+// function to read the totals saved by an earlier run, none means starting from zero
+func loadTotals(path string) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Failed to read analytics file: %v", err)
+	}
+	err = json.Unmarshal(data, &totals)
+	if err != nil {
+		log.Fatalf("Invalid analytics file %s: %v", path, err)
+	}
+	if totals.Counts == nil {
+		totals.Counts = make(map[string]int64)
+	}
+	if totals.Next == nil {
+		totals.Next = make(map[int]int64)
+	}
+}
+
+// function to save the totals, written aside and renamed so a crash keeps the last ones
+func saveTotals(path string) error {
+	data, err := json.MarshalIndent(totals, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(path+".tmp", data, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// function to count one event, saved before the bus moves its offset on
+func countEvent(path string, event eventbus.Event) error {
+	totalsMut.Lock()
+	defer totalsMut.Unlock()
+
+	if event.Offset < totals.Next[event.Partition] {
+		return nil
+	}
+	payment, err := eventbus.DecodePayment(event)
+	if err != nil {
+		fmt.Printf("Skipped event %s, not a payment event: %v\n", event.Id(), err)
+	} else {
+		totals.Counts[payment.Type]++
+		switch payment.Type {
+		case eventbus.PaymentCommitted:
+			totals.CommittedVolume += payment.Amount
+		case eventbus.PaymentRefunded:
+			totals.RefundedVolume += payment.Amount
+		}
+	}
+	//the line is at least this long, the next event starts past it
+	totals.Next[event.Partition] = event.Offset + 1
+	changed = true
+	return saveTotals(path)
+}
+
+// function to print the totals every reportInterval, skipped while nothing came in
+func reportPeriodically() {
+	ticker := time.NewTicker(reportInterval)
+	for range ticker.C {
+		totalsMut.Lock()
+		if changed {
+			printTotals()
+			changed = false
+		}
+		totalsMut.Unlock()
+	}
+}
+
+// function to print the totals, caller holds totalsMut
+func printTotals() {
+	types := make([]string, 0, len(totals.Counts))
+	for eventType := range totals.Counts {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+
+	fmt.Printf("Payment analytics at %s\n", time.Now().Format(time.RFC3339))
+	for _, eventType := range types {
+		fmt.Printf("  %-20s %d\n", eventType, totals.Counts[eventType])
+	}
+	fmt.Printf("  committed volume     %.2f\n", totals.CommittedVolume)
+	fmt.Printf("  refunded volume      %.2f\n", totals.RefundedVolume)
+}
+
+// keeps running totals of payments off the event bus, as consumer group analytics
+//
+//	EVENT_BUS_DIR  the bus the services publish to (default event_bus)
+//	ANALYTICS_FILE where the totals are kept across restarts (default payment_analytics.json)
+func main() {
+	path := os.Getenv("ANALYTICS_FILE")
+	if path == "" {
+		path = "payment_analytics.json"
+	}
+	loadTotals(path)
+
+	bus, err := eventbus.OpenDefault()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	defer bus.Close()
+
+	totalsMut.Lock()
+	printTotals()
+	totalsMut.Unlock()
+	go reportPeriodically()
+
+	fmt.Println("Payment analytics consuming", eventbus.TopicPayments)
+	err = bus.Subscribe(context.Background(), eventbus.TopicPayments, "analytics", func(ctx context.Context, event eventbus.Event) error {
+		return countEvent(path, event)
+	})
+	if err != nil {
+		log.Fatalf("Failed to consume payment events: %v", err)
+	}
+}
@@ -1,28 +1,93 @@
 package main
 
 import (
+	"assignment_2/accountnum"
 	authee "assignment_2/auth"
+	"assignment_2/eventbus"
+	"assignment_2/fraud"
+	"assignment_2/merchants"
 	pb "assignment_2/proto"
+	"assignment_2/review"
+	"assignment_2/tlsutil"
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net"
+	"os"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type PaymentGatewayServer struct {
 	pb.UnimplementedPaymentGatewayServer
 }
 
+// operators look at held payments through this, served next to the gateway as it owns their state
+type ReviewServer struct {
+	pb.UnimplementedReviewServiceServer
+}
+
 // Now storing transactions in a map along with their statuses.
 type TransactionInfo struct {
 	Request *pb.TransactionRequest
 	Status  string // e.g., "Pending", "Committed", "Aborted"
+	//user who started it, only they (our services and account managers aside) may confirm or look at it
+	Initiator string
+	//what the fraud rules said when it came in
+	Risk fraud.Assessment
 }
 
+// statuses set by the fraud rules and the manual review
+const (
+	statusPending       = "Pending"
+	statusHeldForReview = "Held for Review"
+	statusDenied        = "Denied"
+	statusRejected      = "Rejected"
+	//approved by an operator, 2PC is running
+	statusProcessing = "Processing"
+	statusCommitted  = "Committed"
+	statusAborted    = "Aborted"
+	//2PC failed for a passing reason, the offline queue retries it
+	statusQueued = "Queued"
+)
+
+// fraud rules run on every new payment, FRAUD_RULES_FILE replaces the defaults
+var fraudRules = fraud.NewEngine(fraud.DefaultConfig())
+
+// currency of payments which don't name one, DEFAULT_CURRENCY changes it
+var defaultCurrency = "INR"
+
+// held payments survive a restart, REVIEW_JOURNAL_FILE moves the journal
+var reviewQueue *review.Queue
+
+// who holds payments in the review journal
+const fraudRulesActor = "fraud-rules"
+
+var twoPhaseCommitAddress = "localhost:50057"
+var authLoadBalancerAddress = "localhost:50055"
+var offlineQueueAddress = "localhost:50059"
+
+// every call to other services goes over tls and carries our service token
+var transportSecurity = grpc.WithTransportCredentials(tlsutil.ClientCredentials(authee.ServicePaymentGateway))
+var serviceIdentity = grpc.WithPerRPCCredentials(authee.NewServiceCredentials(authee.ServicePaymentGateway))
+
+// where merchants being paid are looked up
+var merchantDirectory merchants.Directory = merchants.NewRemoteDirectory(authLoadBalancerAddress, transportSecurity, serviceIdentity)
+
+// lifecycle events of every payment go out here for the logger, webhooks and analytics
+var eventBus eventbus.Bus
+
 var transactions = make(map[string]*TransactionInfo)
 var transMut sync.Mutex
 
@@ -31,6 +96,30 @@ var processedTransations = make(map[string]bool)
 
 // Initiates the transaction and stores it as "Pending"
 func (pg *PaymentGatewayServer) InitiateTransaction(ctx context.Context, req *pb.TransactionRequest) (*pb.TransactionResponse, error) {
+	//a payment naming a merchant goes to its payout account, whatever receiver the payer sent
+	if err := merchants.Route(ctx, merchantDirectory, req); err != nil {
+		return nil, err
+	}
+
+	//mistyped accounts are rejected before anything else happens
+	if err := accountnum.Validate(req.SenderId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Sender: %v", err)
+	}
+	if err := accountnum.Validate(req.RecieverId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Receiver: %v", err)
+	}
+	if err := checkAmount(req.Amount); err != nil {
+		return nil, err
+	}
+	//no currency means ours, not an unusual one for the fraud rules
+	if req.Currency == "" {
+		req.Currency = defaultCurrency
+	}
+	//only the owner of an account may pay out of it
+	if err := authee.AuthorizeAccount(ctx, req.SenderId); err != nil {
+		return nil, err
+	}
+
 	transMut.Lock()
 	defer transMut.Unlock()
 
@@ -49,14 +138,471 @@ func (pg *PaymentGatewayServer) InitiateTransaction(ctx context.Context, req *pb
 		}, nil
 	}
 
+	caller, _ := authee.CallerFromContext(ctx)
+	info, err := admitTransaction(transactionId, req, caller.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.TransactionResponse{
+		TransactionId: transactionId,
+		Status:        info.Status,
+		RiskScore:     int32(info.Risk.Score),
+		RiskDecision:  info.Risk.Decision,
+	}, nil
+}
+
+// function for the payer to go ahead with a pending payment, or call it off with success false
+// the gateway runs 2PC for it, a payment the bank can't take right now comes back Queued
+func (pg *PaymentGatewayServer) ConfirmTransaction(ctx context.Context, req *pb.TransactionConfirmation) (*pb.Response, error) {
+	transMut.Lock()
+	info, exists := transactions[req.TransactionId]
+	if !exists {
+		transMut.Unlock()
+		return nil, status.Errorf(codes.NotFound, "Transaction %s not found", req.TransactionId)
+	}
+	if err := authorizeInitiator(ctx, req.TransactionId, info); err != nil {
+		transMut.Unlock()
+		return nil, err
+	}
+	if info.Status != statusPending {
+		current := info.Status
+		transMut.Unlock()
+		return nil, status.Errorf(codes.FailedPrecondition, "Transaction %s is %s, only pending payments can be confirmed", req.TransactionId, current)
+	}
+	if !req.Success {
+		info.Status = statusAborted
+		transMut.Unlock()
+		fmt.Println("Transaction called off:", req.TransactionId)
+		publishPaymentEvent(eventbus.PaymentAborted, req.TransactionId, info.Request)
+		return &pb.Response{Status: statusAborted}, nil
+	}
+	info.Status = statusProcessing
+	request := info.Request
+	transMut.Unlock()
+
+	return &pb.Response{Status: settlePayment(req.TransactionId, request)}, nil
+}
+
+// function to reject payments of nothing, less than nothing or no number at all before they reach a bank
+func checkAmount(amount float64) error {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) || amount <= 0 {
+		return status.Errorf(codes.InvalidArgument, "Amount must be a positive number, got %v", amount)
+	}
+	return nil
+}
+
+// function to check the caller is the one who started this payment
+// our own services and callers with accounts:manage may act on any payment
+func authorizeInitiator(ctx context.Context, transactionId string, info *TransactionInfo) error {
+	caller, ok := authee.CallerFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "Missing caller")
+	}
+	if caller.Service != "" || authee.HasScope(caller.Role, authee.ScopeAccountsManage) {
+		return nil
+	}
+	if info.Initiator == "" || caller.Username != info.Initiator {
+		return status.Errorf(codes.PermissionDenied, "Transaction %s was not started by %s", transactionId, caller.Username)
+	}
+	return nil
+}
+
+// function to run the fraud rules on a new payment and record it for its initiator, caller holds transMut
+// allowed payments come back Pending, risky ones held for review, a denied one as its deny error
+func admitTransaction(transactionId string, req *pb.TransactionRequest, initiator string) (*TransactionInfo, error) {
 	processedTransations[transactionId] = true
 
-	transactions[transactionId] = &TransactionInfo{
-		Request: req,
-		Status:  "Pending",
+	//risk check before anyone authorizes the payment
+	risk := fraudRules.Evaluate(fraud.Payment{
+		Sender:   req.SenderId,
+		Receiver: req.RecieverId,
+		Amount:   req.Amount,
+		Currency: req.Currency,
+	}, time.Now())
+
+	info := &TransactionInfo{Request: req, Status: statusPending, Risk: risk, Initiator: initiator}
+	transactions[transactionId] = info
+
+	switch risk.Decision {
+	case fraud.DecisionDeny:
+		info.Status = statusDenied
+		fmt.Printf("Transaction %s denied, score %d, rules %s\n", transactionId, risk.Score, strings.Join(risk.Matched, ","))
+		publishPaymentEvent(eventbus.PaymentDenied, transactionId, req)
+		return info, risk.DenyError()
+	case fraud.DecisionReview:
+		err := reviewQueue.Hold(review.Item{
+			TransactionId: transactionId,
+			SenderId:      req.SenderId,
+			ReceiverId:    req.RecieverId,
+			Amount:        req.Amount,
+			Currency:      req.Currency,
+			MerchantId:    req.MerchantId,
+			Initiator:     initiator,
+			Score:         risk.Score,
+			Rules:         risk.Matched,
+		}, fraudRulesActor)
+		if err != nil {
+			//not durably held means not held at all, the payer may try again
+			delete(transactions, transactionId)
+			delete(processedTransations, transactionId)
+			return nil, status.Errorf(codes.Unavailable, "Failed to hold payment for review: %v", err)
+		}
+		info.Status = statusHeldForReview
+		fmt.Printf("Transaction %s held for review, score %d, rules %s\n", transactionId, risk.Score, strings.Join(risk.Matched, ","))
+		publishPaymentEvent(eventbus.PaymentHeld, transactionId, req)
+	default:
+		fmt.Println("Transaction Initiated:", transactionId)
+		publishPaymentEvent(eventbus.PaymentInitiated, transactionId, req)
+	}
+	return info, nil
+}
+
+// function for the offline queue to retry a payment under its original id
+// the id is the idempotency key: a committed payment is never run again, and as the bank
+// applies each debit and credit of an id once, re-running one whose outcome got lost is safe too
+func (pg *PaymentGatewayServer) ReplayTransaction(ctx context.Context, req *pb.TransactionRequest) (*pb.TransactionStatus, error) {
+	if req.TransactionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Replayed transactions need their original transaction id")
+	}
+	if err := accountnum.Validate(req.SenderId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Sender: %v", err)
+	}
+	if err := accountnum.Validate(req.RecieverId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Receiver: %v", err)
+	}
+	if err := checkAmount(req.Amount); err != nil {
+		return nil, err
+	}
+
+	transMut.Lock()
+	info, exists := transactions[req.TransactionId]
+	if !exists {
+		//never seen here (or lost in a restart), it goes through the same checks as a new payment
+		var err error
+		//who started it is lost with it, so only our services and account managers see it from here
+		info, err = admitTransaction(req.TransactionId, req, "")
+		if err != nil {
+			transMut.Unlock()
+			return nil, err
+		}
+		//only queued payments are in the offline queue, so that's what it was
+		if info.Status == statusPending {
+			info.Status = statusQueued
+		}
+	}
+
+	//only a queued payment gets another run, a pending one waits for its payer and an aborted one is over
+	//it shows as queued until a replay settles it
+	if info.Status != statusQueued {
+		current := info.Status
+		transMut.Unlock()
+		return &pb.TransactionStatus{TransactionId: req.TransactionId, Status: current}, nil
+	}
+	request := info.Request
+	transMut.Unlock()
+
+	fmt.Println("Replaying transaction:", req.TransactionId)
+	err := runTwoPhaseCommit(req.TransactionId, request)
+	finalStatus := statusCommitted
+	if isRetryable(err) {
+		//it is still in the offline queue, a later attempt settles it
+		finalStatus = statusQueued
+	} else if err != nil {
+		finalStatus = statusAborted
+	}
+	setTransactionStatus(req.TransactionId, finalStatus)
+
+	return &pb.TransactionStatus{TransactionId: req.TransactionId, Status: finalStatus}, nil
+}
+
+// function for the offline queue to report how a queued payment ended outside a replay
+// Aborted once it is dead-lettered or discarded, Queued again once an operator requeues it
+func (pg *PaymentGatewayServer) SettleQueuedTransaction(ctx context.Context, req *pb.TransactionStatus) (*pb.TransactionStatus, error) {
+	if req.Status != statusAborted && req.Status != statusQueued {
+		return nil, status.Errorf(codes.InvalidArgument, "A queued payment can only end up %s or %s, not %s", statusAborted, statusQueued, req.Status)
+	}
+
+	transMut.Lock()
+	info, exists := transactions[req.TransactionId]
+	if !exists {
+		transMut.Unlock()
+		return nil, status.Errorf(codes.NotFound, "Transaction %s not found", req.TransactionId)
+	}
+	current := info.Status
+	//a payment that got settled some other way meanwhile, e.g. committed by a replay, keeps its status
+	giveUp := req.Status == statusAborted && current == statusQueued
+	takeBack := req.Status == statusQueued && (current == statusAborted || current == statusQueued)
+	if !giveUp && !takeBack {
+		transMut.Unlock()
+		return &pb.TransactionStatus{TransactionId: req.TransactionId, Status: current}, nil
+	}
+	info.Status = req.Status
+	request := info.Request
+	transMut.Unlock()
+
+	if giveUp {
+		//the abort 2PC held back when the payment was queued
+		fmt.Println("Queued transaction given up:", req.TransactionId)
+		publishPaymentEvent(eventbus.PaymentAborted, req.TransactionId, request)
+	} else if current != statusQueued {
+		fmt.Println("Transaction queued again:", req.TransactionId)
+	}
+	return &pb.TransactionStatus{TransactionId: req.TransactionId, Status: req.Status}, nil
+}
+
+// function to list payments waiting for an operator, oldest first
+func (rs *ReviewServer) ListPendingReviews(ctx context.Context, req *pb.Empty) (*pb.ReviewList, error) {
+	list := &pb.ReviewList{}
+	for _, item := range reviewQueue.Pending() {
+		list.Reviews = append(list.Reviews, &pb.ReviewItem{
+			TransactionId: item.TransactionId,
+			SenderId:      item.SenderId,
+			ReceiverId:    item.ReceiverId,
+			Amount:        item.Amount,
+			Currency:      item.Currency,
+			RiskScore:     int32(item.Score),
+			Rules:         item.Rules,
+			HeldAt:        item.HeldAt.Format(time.RFC3339),
+		})
+	}
+	return list, nil
+}
+
+// function to approve a held payment, it then goes through 2PC right away
+func (rs *ReviewServer) ApproveReview(ctx context.Context, req *pb.ReviewDecision) (*pb.TransactionStatus, error) {
+	item, err := resolveReview(ctx, req, review.ActionApproved, statusProcessing)
+	if err != nil {
+		return nil, err
+	}
+
+	fraudRules.Trust(item.SenderId, item.ReceiverId)
+	finalStatus := settlePayment(item.TransactionId, &pb.TransactionRequest{
+		TransactionId: item.TransactionId,
+		SenderId:      item.SenderId,
+		RecieverId:    item.ReceiverId,
+		Amount:        item.Amount,
+		Currency:      item.Currency,
+		MerchantId:    item.MerchantId,
+	})
+
+	return &pb.TransactionStatus{Status: finalStatus}, nil
+}
+
+// function to reject a held payment, it fails for good
+func (rs *ReviewServer) RejectReview(ctx context.Context, req *pb.ReviewDecision) (*pb.TransactionStatus, error) {
+	item, err := resolveReview(ctx, req, review.ActionRejected, statusRejected)
+	if err != nil {
+		return nil, err
+	}
+	publishPaymentEvent(eventbus.PaymentAborted, item.TransactionId, &pb.TransactionRequest{
+		SenderId:   item.SenderId,
+		RecieverId: item.ReceiverId,
+		Amount:     item.Amount,
+		MerchantId: item.MerchantId,
+	})
+	return &pb.TransactionStatus{Status: statusRejected}, nil
+}
+
+// function to record the operator's decision in the journal and move the payment on
+func resolveReview(ctx context.Context, req *pb.ReviewDecision, action, nextStatus string) (review.Item, error) {
+	reviewer := "unknown"
+	caller, ok := authee.CallerFromContext(ctx)
+	if ok {
+		reviewer = caller.Username
+	}
+
+	item, err := reviewQueue.Resolve(req.TransactionId, action, reviewer, req.Note)
+	if errors.Is(err, review.ErrNotPending) {
+		return item, status.Errorf(codes.FailedPrecondition, "Transaction %s is not held for review", req.TransactionId)
+	}
+	if err != nil {
+		return item, status.Errorf(codes.Unavailable, "%v", err)
+	}
+
+	setTransactionStatus(req.TransactionId, nextStatus)
+	fmt.Printf("Transaction %s %s by %s (%s)\n", req.TransactionId, action, reviewer, req.Note)
+	return item, nil
+}
+
+func setTransactionStatus(transactionId, newStatus string) {
+	transMut.Lock()
+	defer transMut.Unlock()
+
+	tx, exists := transactions[transactionId]
+	if exists {
+		tx.Status = newStatus
+	}
+}
+
+// function to run 2PC on behalf of the payer, for confirmed payments, approved reviews and offline queue replays
+// the error says why the payment was not committed, isRetryable tells if it is worth another try
+func runTwoPhaseCommit(transactionId string, request *pb.TransactionRequest) error {
+	details := &pb.TransactionDetails{
+		TransactionId: transactionId,
+		SenderId:      request.SenderId,
+		ReceiverId:    request.RecieverId,
+		Amount:        request.Amount,
+		MerchantId:    request.MerchantId,
+	}
+
+	conn, err := grpc.Dial(twoPhaseCommitAddress, transportSecurity, serviceIdentity)
+	if err != nil {
+		fmt.Println("Failed to connect to 2PC coordinator:", err)
+		return status.Errorf(codes.Unavailable, "Failed to connect to 2PC coordinator: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewTwoPhaseCommitClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	vote, err := client.ReadyToCommitTransaction(ctx, details)
+	if err == nil && !vote.FinalDecision {
+		//a plain no vote is the sender's bank saying the money isn't there
+		err = status.Error(codes.FailedPrecondition, "Insufficient funds")
+	}
+	if err != nil {
+		fmt.Printf("Transaction %s not ready to commit: %v\n", transactionId, err)
+		//a payment the offline queue will retry is not aborted for its merchant yet
+		_, errAbort := client.AbortTransaction(ctx, &pb.TransactionID{TransactionId: transactionId, Retrying: isRetryable(err)})
+		if errAbort != nil {
+			fmt.Println("Failed to abort:", errAbort)
+		}
+		return err
+	}
+
+	_, err = client.CommitTransaction(ctx, details)
+	if err != nil {
+		fmt.Printf("Transaction %s failed to commit: %v\n", transactionId, err)
+		return err
+	}
+	return nil
+}
+
+// function to tell failures worth retrying from final ones
+// an unreachable or slow bank or coordinator may be back later, missing funds or a bad account won't fix themselves
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
 	}
-	fmt.Println("Transaction Initiated:", transactionId)
-	return &pb.TransactionResponse{TransactionId: transactionId, Status: "Pending"}, nil
+	return false
+}
+
+// function to run 2PC for a payment and record how it ended
+// a payment failing for a passing reason goes to the offline queue and stays Queued until its replay settles it
+func settlePayment(transactionId string, request *pb.TransactionRequest) string {
+	err := runTwoPhaseCommit(transactionId, request)
+	if err == nil {
+		setTransactionStatus(transactionId, statusCommitted)
+		return statusCommitted
+	}
+	if !isRetryable(err) {
+		setTransactionStatus(transactionId, statusAborted)
+		return statusAborted
+	}
+
+	//marked before the queue has it, else a quick replay could be overwritten
+	setTransactionStatus(transactionId, statusQueued)
+	errQueue := enqueueForRetry(transactionId, request)
+	if errQueue != nil {
+		//the queue may have it anyway, e.g. when only its answer timed out, so it is not aborted here
+		fmt.Printf("Failed to queue transaction %s for retry, it stays queued: %v\n", transactionId, errQueue)
+	} else {
+		fmt.Printf("Transaction %s queued for retry: %v\n", transactionId, err)
+	}
+	publishPaymentEvent(eventbus.PaymentQueued, transactionId, request)
+	return statusQueued
+}
+
+// function to publish a lifecycle event of a payment, a bus that fails only costs the event, not the payment
+func publishPaymentEvent(eventType, transactionId string, request *pb.TransactionRequest) {
+	err := eventbus.PublishPayment(eventBus, &pb.PaymentEvent{
+		Type:          eventType,
+		TransactionId: transactionId,
+		SenderId:      request.SenderId,
+		ReceiverId:    request.RecieverId,
+		Amount:        request.Amount,
+		MerchantId:    request.MerchantId,
+	})
+	if err != nil {
+		fmt.Printf("Failed to publish %s for %s: %v\n", eventType, transactionId, err)
+	}
+}
+
+// function to hand a payment to the offline queue, it replays it under the same id
+func enqueueForRetry(transactionId string, request *pb.TransactionRequest) error {
+	conn, err := grpc.Dial(offlineQueueAddress, transportSecurity, serviceIdentity)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	queued := proto.Clone(request).(*pb.TransactionRequest)
+	queued.TransactionId = transactionId
+	_, err = pb.NewOfflineQueueServiceClient(conn).ProcessQueuedPayments(ctx, &pb.OfflineRequest{Transactions: []*pb.TransactionRequest{queued}})
+	return err
+}
+
+// function to open the review journal and put held payments back in place after a restart
+func loadReviewQueue() {
+	path := os.Getenv("REVIEW_JOURNAL_FILE")
+	if path == "" {
+		path = "review_journal.jsonl"
+	}
+
+	queue, err := review.Open(path)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	reviewQueue = queue
+
+	transMut.Lock()
+	defer transMut.Unlock()
+
+	pending := queue.Pending()
+	for _, item := range pending {
+		processedTransations[item.TransactionId] = true
+		transactions[item.TransactionId] = &TransactionInfo{
+			Request: &pb.TransactionRequest{
+				TransactionId: item.TransactionId,
+				SenderId:      item.SenderId,
+				RecieverId:    item.ReceiverId,
+				Amount:        item.Amount,
+				Currency:      item.Currency,
+				MerchantId:    item.MerchantId,
+			},
+			Status:    statusHeldForReview,
+			Risk:      fraud.Assessment{Score: item.Score, Decision: fraud.DecisionReview, Matched: item.Rules},
+			Initiator: item.Initiator,
+		}
+	}
+	fmt.Printf("Restored %d payments held for review\n", len(pending))
+}
+
+// function to load fraud rules from the yaml or json file named in env
+// and the currency of payments without one
+func loadFraudRules() {
+	if currency := os.Getenv("DEFAULT_CURRENCY"); currency != "" {
+		defaultCurrency = currency
+	}
+
+	path := os.Getenv("FRAUD_RULES_FILE")
+	if path == "" {
+		return
+	}
+
+	config, err := fraud.LoadConfig(path)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	fraudRules = fraud.NewEngine(config)
+	fmt.Printf("Loaded %d fraud rules\n", len(config.Rules))
 }
 
 // New: GetTransactionStatus implementation to return the status of a transaction
@@ -66,21 +612,42 @@ func (pg *PaymentGatewayServer) GetTransactionStatus(ctx context.Context, req *p
 
 	tx, exists := transactions[req.TransactionId]
 	if !exists {
-		return nil, fmt.Errorf("Transaction not found")
+		return nil, status.Errorf(codes.NotFound, "Transaction %s not found", req.TransactionId)
+	}
+	if err := authorizeInitiator(ctx, req.TransactionId, tx); err != nil {
+		return nil, err
 	}
 	// Return the stored status.
-	return &pb.TransactionStatus{Status: tx.Status}, nil
+	return &pb.TransactionStatus{TransactionId: req.TransactionId, Status: tx.Status}, nil
 }
 
 func main() {
+	//service tokens can't be signed or checked without the shared secret
+	if err := authee.LoadServiceSecret(); err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	listen, err := net.Listen("tcp", ":50052")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
+	loadFraudRules()
+	loadReviewQueue()
+
+	eventBus, err = eventbus.OpenDefault()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	//merchants may call the gateway with their api key instead of a user token
+	authee.EnableAPIKeys(authee.NewRemoteAPIKeyVerifier(authLoadBalancerAddress, transportSecurity, serviceIdentity))
+
 	// Use interceptor for authentication if needed.
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(authee.AuthInterceptor))
+	grpcServer := grpc.NewServer(grpc.Creds(tlsutil.ServerCredentials(authee.ServicePaymentGateway)), grpc.UnaryInterceptor(authee.AuthInterceptor), grpc.StreamInterceptor(authee.StreamAuthInterceptor))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
 	pb.RegisterPaymentGatewayServer(grpcServer, &PaymentGatewayServer{})
+	pb.RegisterReviewServiceServer(grpcServer, &ReviewServer{})
 
 	fmt.Println("Payment Gateway Server running on port 50052...")
 	err = grpcServer.Serve(listen)
@@ -201,6 +201,8 @@ const (
 	BankLoadBalancer_RegisterBankServer_FullMethodName   = "/stripe.BankLoadBalancer/RegisterBankServer"
 	BankLoadBalancer_GetBankServer_FullMethodName        = "/stripe.BankLoadBalancer/GetBankServer"
 	BankLoadBalancer_UpdateBankServerLoad_FullMethodName = "/stripe.BankLoadBalancer/UpdateBankServerLoad"
+	BankLoadBalancer_AssignAccountShard_FullMethodName   = "/stripe.BankLoadBalancer/AssignAccountShard"
+	BankLoadBalancer_GetAccountShard_FullMethodName      = "/stripe.BankLoadBalancer/GetAccountShard"
 )
 
 // BankLoadBalancerClient is the client API for BankLoadBalancer service.
@@ -211,6 +213,8 @@ type BankLoadBalancerClient interface {
 	RegisterBankServer(ctx context.Context, in *ServerInfo, opts ...grpc.CallOption) (*Response, error)
 	GetBankServer(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServerInfo, error)
 	UpdateBankServerLoad(ctx context.Context, in *BankServerLoad, opts ...grpc.CallOption) (*Response, error)
+	AssignAccountShard(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*ServerInfo, error)
+	GetAccountShard(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*ServerInfo, error)
 }
 
 type bankLoadBalancerClient struct {
@@ -261,6 +265,26 @@ func (c *bankLoadBalancerClient) UpdateBankServerLoad(ctx context.Context, in *B
 	return out, nil
 }
 
+func (c *bankLoadBalancerClient) AssignAccountShard(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*ServerInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerInfo)
+	err := c.cc.Invoke(ctx, BankLoadBalancer_AssignAccountShard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankLoadBalancerClient) GetAccountShard(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*ServerInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerInfo)
+	err := c.cc.Invoke(ctx, BankLoadBalancer_GetAccountShard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BankLoadBalancerServer is the server API for BankLoadBalancer service.
 // All implementations must embed UnimplementedBankLoadBalancerServer
 // for forward compatibility.
@@ -269,6 +293,8 @@ type BankLoadBalancerServer interface {
 	RegisterBankServer(context.Context, *ServerInfo) (*Response, error)
 	GetBankServer(context.Context, *Empty) (*ServerInfo, error)
 	UpdateBankServerLoad(context.Context, *BankServerLoad) (*Response, error)
+	AssignAccountShard(context.Context, *AccountRequest) (*ServerInfo, error)
+	GetAccountShard(context.Context, *AccountRequest) (*ServerInfo, error)
 	mustEmbedUnimplementedBankLoadBalancerServer()
 }
 
@@ -291,6 +317,12 @@ func (UnimplementedBankLoadBalancerServer) GetBankServer(context.Context, *Empty
 func (UnimplementedBankLoadBalancerServer) UpdateBankServerLoad(context.Context, *BankServerLoad) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateBankServerLoad not implemented")
 }
+func (UnimplementedBankLoadBalancerServer) AssignAccountShard(context.Context, *AccountRequest) (*ServerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignAccountShard not implemented")
+}
+func (UnimplementedBankLoadBalancerServer) GetAccountShard(context.Context, *AccountRequest) (*ServerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountShard not implemented")
+}
 func (UnimplementedBankLoadBalancerServer) mustEmbedUnimplementedBankLoadBalancerServer() {}
 func (UnimplementedBankLoadBalancerServer) testEmbeddedByValue()                          {}
 
@@ -384,6 +416,42 @@ func _BankLoadBalancer_UpdateBankServerLoad_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BankLoadBalancer_AssignAccountShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankLoadBalancerServer).AssignAccountShard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankLoadBalancer_AssignAccountShard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankLoadBalancerServer).AssignAccountShard(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankLoadBalancer_GetAccountShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankLoadBalancerServer).GetAccountShard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankLoadBalancer_GetAccountShard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankLoadBalancerServer).GetAccountShard(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BankLoadBalancer_ServiceDesc is the grpc.ServiceDesc for BankLoadBalancer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -407,14 +475,33 @@ var BankLoadBalancer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateBankServerLoad",
 			Handler:    _BankLoadBalancer_UpdateBankServerLoad_Handler,
 		},
+		{
+			MethodName: "AssignAccountShard",
+			Handler:    _BankLoadBalancer_AssignAccountShard_Handler,
+		},
+		{
+			MethodName: "GetAccountShard",
+			Handler:    _BankLoadBalancer_GetAccountShard_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "stripe.proto",
 }
 
 const (
-	Authentication_Register_FullMethodName = "/stripe.Authentication/Register"
-	Authentication_Login_FullMethodName    = "/stripe.Authentication/Login"
+	Authentication_Register_FullMethodName       = "/stripe.Authentication/Register"
+	Authentication_Login_FullMethodName          = "/stripe.Authentication/Login"
+	Authentication_AssignRole_FullMethodName     = "/stripe.Authentication/AssignRole"
+	Authentication_AssignTier_FullMethodName     = "/stripe.Authentication/AssignTier"
+	Authentication_EnrollTOTP_FullMethodName     = "/stripe.Authentication/EnrollTOTP"
+	Authentication_ConfirmTOTP_FullMethodName    = "/stripe.Authentication/ConfirmTOTP"
+	Authentication_VerifyTOTP_FullMethodName     = "/stripe.Authentication/VerifyTOTP"
+	Authentication_CreateMerchant_FullMethodName = "/stripe.Authentication/CreateMerchant"
+	Authentication_CreateAPIKey_FullMethodName   = "/stripe.Authentication/CreateAPIKey"
+	Authentication_RevokeAPIKey_FullMethodName   = "/stripe.Authentication/RevokeAPIKey"
+	Authentication_ListAPIKeys_FullMethodName    = "/stripe.Authentication/ListAPIKeys"
+	Authentication_VerifyAPIKey_FullMethodName   = "/stripe.Authentication/VerifyAPIKey"
+	Authentication_GetMerchant_FullMethodName    = "/stripe.Authentication/GetMerchant"
 )
 
 // AuthenticationClient is the client API for Authentication service.
@@ -423,6 +510,17 @@ const (
 type AuthenticationClient interface {
 	Register(ctx context.Context, in *ClientDetails, opts ...grpc.CallOption) (*Response, error)
 	Login(ctx context.Context, in *Credentials, opts ...grpc.CallOption) (*AuthToken, error)
+	AssignRole(ctx context.Context, in *RoleAssignment, opts ...grpc.CallOption) (*Response, error)
+	AssignTier(ctx context.Context, in *TierAssignment, opts ...grpc.CallOption) (*Response, error)
+	EnrollTOTP(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, in *TOTPCode, opts ...grpc.CallOption) (*RecoveryCodes, error)
+	VerifyTOTP(ctx context.Context, in *TOTPVerification, opts ...grpc.CallOption) (*AuthToken, error)
+	CreateMerchant(ctx context.Context, in *MerchantDetails, opts ...grpc.CallOption) (*MerchantDetails, error)
+	CreateAPIKey(ctx context.Context, in *MerchantId, opts ...grpc.CallOption) (*APIKey, error)
+	RevokeAPIKey(ctx context.Context, in *APIKey, opts ...grpc.CallOption) (*Response, error)
+	ListAPIKeys(ctx context.Context, in *MerchantId, opts ...grpc.CallOption) (*APIKeyList, error)
+	VerifyAPIKey(ctx context.Context, in *APIKey, opts ...grpc.CallOption) (*MerchantDetails, error)
+	GetMerchant(ctx context.Context, in *MerchantId, opts ...grpc.CallOption) (*MerchantDetails, error)
 }
 
 type authenticationClient struct {
@@ -453,12 +551,133 @@ func (c *authenticationClient) Login(ctx context.Context, in *Credentials, opts
 	return out, nil
 }
 
+func (c *authenticationClient) AssignRole(ctx context.Context, in *RoleAssignment, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Authentication_AssignRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) AssignTier(ctx context.Context, in *TierAssignment, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Authentication_AssignTier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) EnrollTOTP(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TOTPEnrollment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TOTPEnrollment)
+	err := c.cc.Invoke(ctx, Authentication_EnrollTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ConfirmTOTP(ctx context.Context, in *TOTPCode, opts ...grpc.CallOption) (*RecoveryCodes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoveryCodes)
+	err := c.cc.Invoke(ctx, Authentication_ConfirmTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) VerifyTOTP(ctx context.Context, in *TOTPVerification, opts ...grpc.CallOption) (*AuthToken, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthToken)
+	err := c.cc.Invoke(ctx, Authentication_VerifyTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) CreateMerchant(ctx context.Context, in *MerchantDetails, opts ...grpc.CallOption) (*MerchantDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MerchantDetails)
+	err := c.cc.Invoke(ctx, Authentication_CreateMerchant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) CreateAPIKey(ctx context.Context, in *MerchantId, opts ...grpc.CallOption) (*APIKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKey)
+	err := c.cc.Invoke(ctx, Authentication_CreateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) RevokeAPIKey(ctx context.Context, in *APIKey, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Authentication_RevokeAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ListAPIKeys(ctx context.Context, in *MerchantId, opts ...grpc.CallOption) (*APIKeyList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKeyList)
+	err := c.cc.Invoke(ctx, Authentication_ListAPIKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) VerifyAPIKey(ctx context.Context, in *APIKey, opts ...grpc.CallOption) (*MerchantDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MerchantDetails)
+	err := c.cc.Invoke(ctx, Authentication_VerifyAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) GetMerchant(ctx context.Context, in *MerchantId, opts ...grpc.CallOption) (*MerchantDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MerchantDetails)
+	err := c.cc.Invoke(ctx, Authentication_GetMerchant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthenticationServer is the server API for Authentication service.
 // All implementations must embed UnimplementedAuthenticationServer
 // for forward compatibility.
 type AuthenticationServer interface {
 	Register(context.Context, *ClientDetails) (*Response, error)
 	Login(context.Context, *Credentials) (*AuthToken, error)
+	AssignRole(context.Context, *RoleAssignment) (*Response, error)
+	AssignTier(context.Context, *TierAssignment) (*Response, error)
+	EnrollTOTP(context.Context, *Empty) (*TOTPEnrollment, error)
+	ConfirmTOTP(context.Context, *TOTPCode) (*RecoveryCodes, error)
+	VerifyTOTP(context.Context, *TOTPVerification) (*AuthToken, error)
+	CreateMerchant(context.Context, *MerchantDetails) (*MerchantDetails, error)
+	CreateAPIKey(context.Context, *MerchantId) (*APIKey, error)
+	RevokeAPIKey(context.Context, *APIKey) (*Response, error)
+	ListAPIKeys(context.Context, *MerchantId) (*APIKeyList, error)
+	VerifyAPIKey(context.Context, *APIKey) (*MerchantDetails, error)
+	GetMerchant(context.Context, *MerchantId) (*MerchantDetails, error)
 	mustEmbedUnimplementedAuthenticationServer()
 }
 
@@ -475,6 +694,39 @@ func (UnimplementedAuthenticationServer) Register(context.Context, *ClientDetail
 func (UnimplementedAuthenticationServer) Login(context.Context, *Credentials) (*AuthToken, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
+func (UnimplementedAuthenticationServer) AssignRole(context.Context, *RoleAssignment) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignRole not implemented")
+}
+func (UnimplementedAuthenticationServer) AssignTier(context.Context, *TierAssignment) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignTier not implemented")
+}
+func (UnimplementedAuthenticationServer) EnrollTOTP(context.Context, *Empty) (*TOTPEnrollment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTOTP not implemented")
+}
+func (UnimplementedAuthenticationServer) ConfirmTOTP(context.Context, *TOTPCode) (*RecoveryCodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTP not implemented")
+}
+func (UnimplementedAuthenticationServer) VerifyTOTP(context.Context, *TOTPVerification) (*AuthToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTOTP not implemented")
+}
+func (UnimplementedAuthenticationServer) CreateMerchant(context.Context, *MerchantDetails) (*MerchantDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMerchant not implemented")
+}
+func (UnimplementedAuthenticationServer) CreateAPIKey(context.Context, *MerchantId) (*APIKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedAuthenticationServer) RevokeAPIKey(context.Context, *APIKey) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (UnimplementedAuthenticationServer) ListAPIKeys(context.Context, *MerchantId) (*APIKeyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (UnimplementedAuthenticationServer) VerifyAPIKey(context.Context, *APIKey) (*MerchantDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAPIKey not implemented")
+}
+func (UnimplementedAuthenticationServer) GetMerchant(context.Context, *MerchantId) (*MerchantDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMerchant not implemented")
+}
 func (UnimplementedAuthenticationServer) mustEmbedUnimplementedAuthenticationServer() {}
 func (UnimplementedAuthenticationServer) testEmbeddedByValue()                        {}
 
@@ -532,99 +784,367 @@ func _Authentication_Login_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
-// Authentication_ServiceDesc is the grpc.ServiceDesc for Authentication service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Authentication_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "stripe.Authentication",
-	HandlerType: (*AuthenticationServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Register",
-			Handler:    _Authentication_Register_Handler,
-		},
-		{
-			MethodName: "Login",
-			Handler:    _Authentication_Login_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "stripe.proto",
-}
-
-const (
-	PaymentGateway_InitiateTransaction_FullMethodName   = "/stripe.PaymentGateway/InitiateTransaction"
-	PaymentGateway_ConfirmTransaction_FullMethodName    = "/stripe.PaymentGateway/ConfirmTransaction"
-	PaymentGateway_GetTransactionStatus_FullMethodName  = "/stripe.PaymentGateway/GetTransactionStatus"
-	PaymentGateway_ProcessQueuedPayments_FullMethodName = "/stripe.PaymentGateway/ProcessQueuedPayments"
-)
-
-// PaymentGatewayClient is the client API for PaymentGateway service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type PaymentGatewayClient interface {
-	InitiateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
-	ConfirmTransaction(ctx context.Context, in *TransactionConfirmation, opts ...grpc.CallOption) (*Response, error)
-	GetTransactionStatus(ctx context.Context, in *TransactionID, opts ...grpc.CallOption) (*TransactionStatus, error)
-	ProcessQueuedPayments(ctx context.Context, in *OfflineRequest, opts ...grpc.CallOption) (*Response, error)
-}
-
-type paymentGatewayClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewPaymentGatewayClient(cc grpc.ClientConnInterface) PaymentGatewayClient {
-	return &paymentGatewayClient{cc}
+func _Authentication_AssignRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleAssignment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).AssignRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authentication_AssignRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).AssignRole(ctx, req.(*RoleAssignment))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *paymentGatewayClient) InitiateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(TransactionResponse)
-	err := c.cc.Invoke(ctx, PaymentGateway_InitiateTransaction_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Authentication_AssignTier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TierAssignment)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AuthenticationServer).AssignTier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authentication_AssignTier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).AssignTier(ctx, req.(*TierAssignment))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *paymentGatewayClient) ConfirmTransaction(ctx context.Context, in *TransactionConfirmation, opts ...grpc.CallOption) (*Response, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Response)
-	err := c.cc.Invoke(ctx, PaymentGateway_ConfirmTransaction_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Authentication_EnrollTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AuthenticationServer).EnrollTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authentication_EnrollTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).EnrollTOTP(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *paymentGatewayClient) GetTransactionStatus(ctx context.Context, in *TransactionID, opts ...grpc.CallOption) (*TransactionStatus, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(TransactionStatus)
-	err := c.cc.Invoke(ctx, PaymentGateway_GetTransactionStatus_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Authentication_ConfirmTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TOTPCode)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ConfirmTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authentication_ConfirmTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ConfirmTOTP(ctx, req.(*TOTPCode))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *paymentGatewayClient) ProcessQueuedPayments(ctx context.Context, in *OfflineRequest, opts ...grpc.CallOption) (*Response, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Response)
-	err := c.cc.Invoke(ctx, PaymentGateway_ProcessQueuedPayments_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Authentication_VerifyTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TOTPVerification)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AuthenticationServer).VerifyTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authentication_VerifyTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).VerifyTOTP(ctx, req.(*TOTPVerification))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// PaymentGatewayServer is the server API for PaymentGateway service.
-// All implementations must embed UnimplementedPaymentGatewayServer
-// for forward compatibility.
-type PaymentGatewayServer interface {
-	InitiateTransaction(context.Context, *TransactionRequest) (*TransactionResponse, error)
+func _Authentication_CreateMerchant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerchantDetails)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).CreateMerchant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authentication_CreateMerchant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).CreateMerchant(ctx, req.(*MerchantDetails))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerchantId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authentication_CreateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).CreateAPIKey(ctx, req.(*MerchantId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authentication_RevokeAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).RevokeAPIKey(ctx, req.(*APIKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerchantId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authentication_ListAPIKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ListAPIKeys(ctx, req.(*MerchantId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_VerifyAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).VerifyAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authentication_VerifyAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).VerifyAPIKey(ctx, req.(*APIKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_GetMerchant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerchantId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).GetMerchant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authentication_GetMerchant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).GetMerchant(ctx, req.(*MerchantId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Authentication_ServiceDesc is the grpc.ServiceDesc for Authentication service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Authentication_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stripe.Authentication",
+	HandlerType: (*AuthenticationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _Authentication_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _Authentication_Login_Handler,
+		},
+		{
+			MethodName: "AssignRole",
+			Handler:    _Authentication_AssignRole_Handler,
+		},
+		{
+			MethodName: "AssignTier",
+			Handler:    _Authentication_AssignTier_Handler,
+		},
+		{
+			MethodName: "EnrollTOTP",
+			Handler:    _Authentication_EnrollTOTP_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTP",
+			Handler:    _Authentication_ConfirmTOTP_Handler,
+		},
+		{
+			MethodName: "VerifyTOTP",
+			Handler:    _Authentication_VerifyTOTP_Handler,
+		},
+		{
+			MethodName: "CreateMerchant",
+			Handler:    _Authentication_CreateMerchant_Handler,
+		},
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _Authentication_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _Authentication_RevokeAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _Authentication_ListAPIKeys_Handler,
+		},
+		{
+			MethodName: "VerifyAPIKey",
+			Handler:    _Authentication_VerifyAPIKey_Handler,
+		},
+		{
+			MethodName: "GetMerchant",
+			Handler:    _Authentication_GetMerchant_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "stripe.proto",
+}
+
+const (
+	PaymentGateway_InitiateTransaction_FullMethodName     = "/stripe.PaymentGateway/InitiateTransaction"
+	PaymentGateway_ConfirmTransaction_FullMethodName      = "/stripe.PaymentGateway/ConfirmTransaction"
+	PaymentGateway_GetTransactionStatus_FullMethodName    = "/stripe.PaymentGateway/GetTransactionStatus"
+	PaymentGateway_ProcessQueuedPayments_FullMethodName   = "/stripe.PaymentGateway/ProcessQueuedPayments"
+	PaymentGateway_ReplayTransaction_FullMethodName       = "/stripe.PaymentGateway/ReplayTransaction"
+	PaymentGateway_SettleQueuedTransaction_FullMethodName = "/stripe.PaymentGateway/SettleQueuedTransaction"
+)
+
+// PaymentGatewayClient is the client API for PaymentGateway service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PaymentGatewayClient interface {
+	InitiateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	ConfirmTransaction(ctx context.Context, in *TransactionConfirmation, opts ...grpc.CallOption) (*Response, error)
+	GetTransactionStatus(ctx context.Context, in *TransactionID, opts ...grpc.CallOption) (*TransactionStatus, error)
+	ProcessQueuedPayments(ctx context.Context, in *OfflineRequest, opts ...grpc.CallOption) (*Response, error)
+	ReplayTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionStatus, error)
+	SettleQueuedTransaction(ctx context.Context, in *TransactionStatus, opts ...grpc.CallOption) (*TransactionStatus, error)
+}
+
+type paymentGatewayClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPaymentGatewayClient(cc grpc.ClientConnInterface) PaymentGatewayClient {
+	return &paymentGatewayClient{cc}
+}
+
+func (c *paymentGatewayClient) InitiateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, PaymentGateway_InitiateTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentGatewayClient) ConfirmTransaction(ctx context.Context, in *TransactionConfirmation, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, PaymentGateway_ConfirmTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentGatewayClient) GetTransactionStatus(ctx context.Context, in *TransactionID, opts ...grpc.CallOption) (*TransactionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionStatus)
+	err := c.cc.Invoke(ctx, PaymentGateway_GetTransactionStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentGatewayClient) ProcessQueuedPayments(ctx context.Context, in *OfflineRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, PaymentGateway_ProcessQueuedPayments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentGatewayClient) ReplayTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionStatus)
+	err := c.cc.Invoke(ctx, PaymentGateway_ReplayTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentGatewayClient) SettleQueuedTransaction(ctx context.Context, in *TransactionStatus, opts ...grpc.CallOption) (*TransactionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionStatus)
+	err := c.cc.Invoke(ctx, PaymentGateway_SettleQueuedTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PaymentGatewayServer is the server API for PaymentGateway service.
+// All implementations must embed UnimplementedPaymentGatewayServer
+// for forward compatibility.
+type PaymentGatewayServer interface {
+	InitiateTransaction(context.Context, *TransactionRequest) (*TransactionResponse, error)
 	ConfirmTransaction(context.Context, *TransactionConfirmation) (*Response, error)
 	GetTransactionStatus(context.Context, *TransactionID) (*TransactionStatus, error)
 	ProcessQueuedPayments(context.Context, *OfflineRequest) (*Response, error)
+	ReplayTransaction(context.Context, *TransactionRequest) (*TransactionStatus, error)
+	SettleQueuedTransaction(context.Context, *TransactionStatus) (*TransactionStatus, error)
 	mustEmbedUnimplementedPaymentGatewayServer()
 }
 
@@ -647,121 +1167,603 @@ func (UnimplementedPaymentGatewayServer) GetTransactionStatus(context.Context, *
 func (UnimplementedPaymentGatewayServer) ProcessQueuedPayments(context.Context, *OfflineRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProcessQueuedPayments not implemented")
 }
+func (UnimplementedPaymentGatewayServer) ReplayTransaction(context.Context, *TransactionRequest) (*TransactionStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayTransaction not implemented")
+}
+func (UnimplementedPaymentGatewayServer) SettleQueuedTransaction(context.Context, *TransactionStatus) (*TransactionStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SettleQueuedTransaction not implemented")
+}
 func (UnimplementedPaymentGatewayServer) mustEmbedUnimplementedPaymentGatewayServer() {}
 func (UnimplementedPaymentGatewayServer) testEmbeddedByValue()                        {}
 
-// UnsafePaymentGatewayServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to PaymentGatewayServer will
+// UnsafePaymentGatewayServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PaymentGatewayServer will
+// result in compilation errors.
+type UnsafePaymentGatewayServer interface {
+	mustEmbedUnimplementedPaymentGatewayServer()
+}
+
+func RegisterPaymentGatewayServer(s grpc.ServiceRegistrar, srv PaymentGatewayServer) {
+	// If the following call pancis, it indicates UnimplementedPaymentGatewayServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PaymentGateway_ServiceDesc, srv)
+}
+
+func _PaymentGateway_InitiateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentGatewayServer).InitiateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentGateway_InitiateTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentGatewayServer).InitiateTransaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentGateway_ConfirmTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionConfirmation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentGatewayServer).ConfirmTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentGateway_ConfirmTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentGatewayServer).ConfirmTransaction(ctx, req.(*TransactionConfirmation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentGateway_GetTransactionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentGatewayServer).GetTransactionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentGateway_GetTransactionStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentGatewayServer).GetTransactionStatus(ctx, req.(*TransactionID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentGateway_ProcessQueuedPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfflineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentGatewayServer).ProcessQueuedPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentGateway_ProcessQueuedPayments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentGatewayServer).ProcessQueuedPayments(ctx, req.(*OfflineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentGateway_ReplayTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentGatewayServer).ReplayTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentGateway_ReplayTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentGatewayServer).ReplayTransaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentGateway_SettleQueuedTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionStatus)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentGatewayServer).SettleQueuedTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentGateway_SettleQueuedTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentGatewayServer).SettleQueuedTransaction(ctx, req.(*TransactionStatus))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PaymentGateway_ServiceDesc is the grpc.ServiceDesc for PaymentGateway service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PaymentGateway_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stripe.PaymentGateway",
+	HandlerType: (*PaymentGatewayServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InitiateTransaction",
+			Handler:    _PaymentGateway_InitiateTransaction_Handler,
+		},
+		{
+			MethodName: "ConfirmTransaction",
+			Handler:    _PaymentGateway_ConfirmTransaction_Handler,
+		},
+		{
+			MethodName: "GetTransactionStatus",
+			Handler:    _PaymentGateway_GetTransactionStatus_Handler,
+		},
+		{
+			MethodName: "ProcessQueuedPayments",
+			Handler:    _PaymentGateway_ProcessQueuedPayments_Handler,
+		},
+		{
+			MethodName: "ReplayTransaction",
+			Handler:    _PaymentGateway_ReplayTransaction_Handler,
+		},
+		{
+			MethodName: "SettleQueuedTransaction",
+			Handler:    _PaymentGateway_SettleQueuedTransaction_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "stripe.proto",
+}
+
+const (
+	WebhookService_RegisterWebhook_FullMethodName = "/stripe.WebhookService/RegisterWebhook"
+	WebhookService_ListWebhooks_FullMethodName    = "/stripe.WebhookService/ListWebhooks"
+	WebhookService_DeleteWebhook_FullMethodName   = "/stripe.WebhookService/DeleteWebhook"
+	WebhookService_ListDeadLetters_FullMethodName = "/stripe.WebhookService/ListDeadLetters"
+	WebhookService_ReplayDelivery_FullMethodName  = "/stripe.WebhookService/ReplayDelivery"
+)
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	RegisterWebhook(ctx context.Context, in *WebhookEndpoint, opts ...grpc.CallOption) (*WebhookEndpoint, error)
+	ListWebhooks(ctx context.Context, in *MerchantId, opts ...grpc.CallOption) (*WebhookEndpointList, error)
+	DeleteWebhook(ctx context.Context, in *WebhookEndpoint, opts ...grpc.CallOption) (*Response, error)
+	ListDeadLetters(ctx context.Context, in *MerchantId, opts ...grpc.CallOption) (*WebhookDeliveryList, error)
+	ReplayDelivery(ctx context.Context, in *WebhookDelivery, opts ...grpc.CallOption) (*WebhookDelivery, error)
+}
+
+type webhookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookServiceClient(cc grpc.ClientConnInterface) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) RegisterWebhook(ctx context.Context, in *WebhookEndpoint, opts ...grpc.CallOption) (*WebhookEndpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookEndpoint)
+	err := c.cc.Invoke(ctx, WebhookService_RegisterWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhooks(ctx context.Context, in *MerchantId, opts ...grpc.CallOption) (*WebhookEndpointList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookEndpointList)
+	err := c.cc.Invoke(ctx, WebhookService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) DeleteWebhook(ctx context.Context, in *WebhookEndpoint, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, WebhookService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListDeadLetters(ctx context.Context, in *MerchantId, opts ...grpc.CallOption) (*WebhookDeliveryList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDeliveryList)
+	err := c.cc.Invoke(ctx, WebhookService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ReplayDelivery(ctx context.Context, in *WebhookDelivery, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, WebhookService_ReplayDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+// All implementations must embed UnimplementedWebhookServiceServer
+// for forward compatibility.
+type WebhookServiceServer interface {
+	RegisterWebhook(context.Context, *WebhookEndpoint) (*WebhookEndpoint, error)
+	ListWebhooks(context.Context, *MerchantId) (*WebhookEndpointList, error)
+	DeleteWebhook(context.Context, *WebhookEndpoint) (*Response, error)
+	ListDeadLetters(context.Context, *MerchantId) (*WebhookDeliveryList, error)
+	ReplayDelivery(context.Context, *WebhookDelivery) (*WebhookDelivery, error)
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+// UnimplementedWebhookServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebhookServiceServer struct{}
+
+func (UnimplementedWebhookServiceServer) RegisterWebhook(context.Context, *WebhookEndpoint) (*WebhookEndpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) ListWebhooks(context.Context, *MerchantId) (*WebhookEndpointList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedWebhookServiceServer) DeleteWebhook(context.Context, *WebhookEndpoint) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) ListDeadLetters(context.Context, *MerchantId) (*WebhookDeliveryList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedWebhookServiceServer) ReplayDelivery(context.Context, *WebhookDelivery) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDelivery not implemented")
+}
+func (UnimplementedWebhookServiceServer) mustEmbedUnimplementedWebhookServiceServer() {}
+func (UnimplementedWebhookServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeWebhookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookServiceServer will
+// result in compilation errors.
+type UnsafeWebhookServiceServer interface {
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+func RegisterWebhookServiceServer(s grpc.ServiceRegistrar, srv WebhookServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWebhookServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WebhookService_ServiceDesc, srv)
+}
+
+func _WebhookService_RegisterWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookEndpoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).RegisterWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_RegisterWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).RegisterWebhook(ctx, req.(*WebhookEndpoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerchantId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, req.(*MerchantId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookEndpoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, req.(*WebhookEndpoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerchantId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListDeadLetters(ctx, req.(*MerchantId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ReplayDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookDelivery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ReplayDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_ReplayDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ReplayDelivery(ctx, req.(*WebhookDelivery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebhookService_ServiceDesc is the grpc.ServiceDesc for WebhookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stripe.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterWebhook",
+			Handler:    _WebhookService_RegisterWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _WebhookService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _WebhookService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _WebhookService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "ReplayDelivery",
+			Handler:    _WebhookService_ReplayDelivery_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "stripe.proto",
+}
+
+const (
+	ReviewService_ListPendingReviews_FullMethodName = "/stripe.ReviewService/ListPendingReviews"
+	ReviewService_ApproveReview_FullMethodName      = "/stripe.ReviewService/ApproveReview"
+	ReviewService_RejectReview_FullMethodName       = "/stripe.ReviewService/RejectReview"
+)
+
+// ReviewServiceClient is the client API for ReviewService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReviewServiceClient interface {
+	ListPendingReviews(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReviewList, error)
+	ApproveReview(ctx context.Context, in *ReviewDecision, opts ...grpc.CallOption) (*TransactionStatus, error)
+	RejectReview(ctx context.Context, in *ReviewDecision, opts ...grpc.CallOption) (*TransactionStatus, error)
+}
+
+type reviewServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReviewServiceClient(cc grpc.ClientConnInterface) ReviewServiceClient {
+	return &reviewServiceClient{cc}
+}
+
+func (c *reviewServiceClient) ListPendingReviews(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReviewList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewList)
+	err := c.cc.Invoke(ctx, ReviewService_ListPendingReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewServiceClient) ApproveReview(ctx context.Context, in *ReviewDecision, opts ...grpc.CallOption) (*TransactionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionStatus)
+	err := c.cc.Invoke(ctx, ReviewService_ApproveReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewServiceClient) RejectReview(ctx context.Context, in *ReviewDecision, opts ...grpc.CallOption) (*TransactionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionStatus)
+	err := c.cc.Invoke(ctx, ReviewService_RejectReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReviewServiceServer is the server API for ReviewService service.
+// All implementations must embed UnimplementedReviewServiceServer
+// for forward compatibility.
+type ReviewServiceServer interface {
+	ListPendingReviews(context.Context, *Empty) (*ReviewList, error)
+	ApproveReview(context.Context, *ReviewDecision) (*TransactionStatus, error)
+	RejectReview(context.Context, *ReviewDecision) (*TransactionStatus, error)
+	mustEmbedUnimplementedReviewServiceServer()
+}
+
+// UnimplementedReviewServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReviewServiceServer struct{}
+
+func (UnimplementedReviewServiceServer) ListPendingReviews(context.Context, *Empty) (*ReviewList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingReviews not implemented")
+}
+func (UnimplementedReviewServiceServer) ApproveReview(context.Context, *ReviewDecision) (*TransactionStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveReview not implemented")
+}
+func (UnimplementedReviewServiceServer) RejectReview(context.Context, *ReviewDecision) (*TransactionStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectReview not implemented")
+}
+func (UnimplementedReviewServiceServer) mustEmbedUnimplementedReviewServiceServer() {}
+func (UnimplementedReviewServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeReviewServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReviewServiceServer will
 // result in compilation errors.
-type UnsafePaymentGatewayServer interface {
-	mustEmbedUnimplementedPaymentGatewayServer()
+type UnsafeReviewServiceServer interface {
+	mustEmbedUnimplementedReviewServiceServer()
 }
 
-func RegisterPaymentGatewayServer(s grpc.ServiceRegistrar, srv PaymentGatewayServer) {
-	// If the following call pancis, it indicates UnimplementedPaymentGatewayServer was
+func RegisterReviewServiceServer(s grpc.ServiceRegistrar, srv ReviewServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReviewServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&PaymentGateway_ServiceDesc, srv)
-}
-
-func _PaymentGateway_InitiateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransactionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PaymentGatewayServer).InitiateTransaction(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: PaymentGateway_InitiateTransaction_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PaymentGatewayServer).InitiateTransaction(ctx, req.(*TransactionRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	s.RegisterService(&ReviewService_ServiceDesc, srv)
 }
 
-func _PaymentGateway_ConfirmTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransactionConfirmation)
+func _ReviewService_ListPendingReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PaymentGatewayServer).ConfirmTransaction(ctx, in)
+		return srv.(ReviewServiceServer).ListPendingReviews(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PaymentGateway_ConfirmTransaction_FullMethodName,
+		FullMethod: ReviewService_ListPendingReviews_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PaymentGatewayServer).ConfirmTransaction(ctx, req.(*TransactionConfirmation))
+		return srv.(ReviewServiceServer).ListPendingReviews(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PaymentGateway_GetTransactionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransactionID)
+func _ReviewService_ApproveReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewDecision)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PaymentGatewayServer).GetTransactionStatus(ctx, in)
+		return srv.(ReviewServiceServer).ApproveReview(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PaymentGateway_GetTransactionStatus_FullMethodName,
+		FullMethod: ReviewService_ApproveReview_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PaymentGatewayServer).GetTransactionStatus(ctx, req.(*TransactionID))
+		return srv.(ReviewServiceServer).ApproveReview(ctx, req.(*ReviewDecision))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PaymentGateway_ProcessQueuedPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OfflineRequest)
+func _ReviewService_RejectReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewDecision)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PaymentGatewayServer).ProcessQueuedPayments(ctx, in)
+		return srv.(ReviewServiceServer).RejectReview(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PaymentGateway_ProcessQueuedPayments_FullMethodName,
+		FullMethod: ReviewService_RejectReview_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PaymentGatewayServer).ProcessQueuedPayments(ctx, req.(*OfflineRequest))
+		return srv.(ReviewServiceServer).RejectReview(ctx, req.(*ReviewDecision))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// PaymentGateway_ServiceDesc is the grpc.ServiceDesc for PaymentGateway service.
+// ReviewService_ServiceDesc is the grpc.ServiceDesc for ReviewService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var PaymentGateway_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "stripe.PaymentGateway",
-	HandlerType: (*PaymentGatewayServer)(nil),
+var ReviewService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stripe.ReviewService",
+	HandlerType: (*ReviewServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "InitiateTransaction",
-			Handler:    _PaymentGateway_InitiateTransaction_Handler,
-		},
-		{
-			MethodName: "ConfirmTransaction",
-			Handler:    _PaymentGateway_ConfirmTransaction_Handler,
+			MethodName: "ListPendingReviews",
+			Handler:    _ReviewService_ListPendingReviews_Handler,
 		},
 		{
-			MethodName: "GetTransactionStatus",
-			Handler:    _PaymentGateway_GetTransactionStatus_Handler,
+			MethodName: "ApproveReview",
+			Handler:    _ReviewService_ApproveReview_Handler,
 		},
 		{
-			MethodName: "ProcessQueuedPayments",
-			Handler:    _PaymentGateway_ProcessQueuedPayments_Handler,
+			MethodName: "RejectReview",
+			Handler:    _ReviewService_RejectReview_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -774,6 +1776,15 @@ const (
 	BankServer_RegisterUser_FullMethodName     = "/stripe.BankServer/RegisterUser"
 	BankServer_DepositMoney_FullMethodName     = "/stripe.BankServer/DepositMoney"
 	BankServer_AbortTransaction_FullMethodName = "/stripe.BankServer/AbortTransaction"
+	BankServer_RevokeAccount_FullMethodName    = "/stripe.BankServer/RevokeAccount"
+	BankServer_ListAccounts_FullMethodName     = "/stripe.BankServer/ListAccounts"
+	BankServer_GetBalance_FullMethodName       = "/stripe.BankServer/GetBalance"
+	BankServer_FreezeAccount_FullMethodName    = "/stripe.BankServer/FreezeAccount"
+	BankServer_UnfreezeAccount_FullMethodName  = "/stripe.BankServer/UnfreezeAccount"
+	BankServer_CloseAccount_FullMethodName     = "/stripe.BankServer/CloseAccount"
+	BankServer_FundAccount_FullMethodName      = "/stripe.BankServer/FundAccount"
+	BankServer_SetAccountTier_FullMethodName   = "/stripe.BankServer/SetAccountTier"
+	BankServer_ReverseDeposit_FullMethodName   = "/stripe.BankServer/ReverseDeposit"
 )
 
 // BankServerClient is the client API for BankServer service.
@@ -785,6 +1796,15 @@ type BankServerClient interface {
 	RegisterUser(ctx context.Context, in *ClientDetails, opts ...grpc.CallOption) (*Response, error)
 	DepositMoney(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*DepositResponse, error)
 	AbortTransaction(ctx context.Context, in *TransactionID, opts ...grpc.CallOption) (*Response, error)
+	RevokeAccount(ctx context.Context, in *ClientDetails, opts ...grpc.CallOption) (*Response, error)
+	ListAccounts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccountList, error)
+	GetBalance(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
+	FreezeAccount(ctx context.Context, in *AccountStatusChange, opts ...grpc.CallOption) (*Response, error)
+	UnfreezeAccount(ctx context.Context, in *AccountStatusChange, opts ...grpc.CallOption) (*Response, error)
+	CloseAccount(ctx context.Context, in *AccountStatusChange, opts ...grpc.CallOption) (*Response, error)
+	FundAccount(ctx context.Context, in *FundingRequest, opts ...grpc.CallOption) (*DepositResponse, error)
+	SetAccountTier(ctx context.Context, in *TierAssignment, opts ...grpc.CallOption) (*Response, error)
+	ReverseDeposit(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*DepositResponse, error)
 }
 
 type bankServerClient struct {
@@ -845,6 +1865,96 @@ func (c *bankServerClient) AbortTransaction(ctx context.Context, in *Transaction
 	return out, nil
 }
 
+func (c *bankServerClient) RevokeAccount(ctx context.Context, in *ClientDetails, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, BankServer_RevokeAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServerClient) ListAccounts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccountList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountList)
+	err := c.cc.Invoke(ctx, BankServer_ListAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServerClient) GetBalance(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*BalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BalanceResponse)
+	err := c.cc.Invoke(ctx, BankServer_GetBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServerClient) FreezeAccount(ctx context.Context, in *AccountStatusChange, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, BankServer_FreezeAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServerClient) UnfreezeAccount(ctx context.Context, in *AccountStatusChange, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, BankServer_UnfreezeAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServerClient) CloseAccount(ctx context.Context, in *AccountStatusChange, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, BankServer_CloseAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServerClient) FundAccount(ctx context.Context, in *FundingRequest, opts ...grpc.CallOption) (*DepositResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DepositResponse)
+	err := c.cc.Invoke(ctx, BankServer_FundAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServerClient) SetAccountTier(ctx context.Context, in *TierAssignment, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, BankServer_SetAccountTier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServerClient) ReverseDeposit(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*DepositResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DepositResponse)
+	err := c.cc.Invoke(ctx, BankServer_ReverseDeposit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BankServerServer is the server API for BankServer service.
 // All implementations must embed UnimplementedBankServerServer
 // for forward compatibility.
@@ -854,6 +1964,15 @@ type BankServerServer interface {
 	RegisterUser(context.Context, *ClientDetails) (*Response, error)
 	DepositMoney(context.Context, *DepositRequest) (*DepositResponse, error)
 	AbortTransaction(context.Context, *TransactionID) (*Response, error)
+	RevokeAccount(context.Context, *ClientDetails) (*Response, error)
+	ListAccounts(context.Context, *Empty) (*AccountList, error)
+	GetBalance(context.Context, *AccountRequest) (*BalanceResponse, error)
+	FreezeAccount(context.Context, *AccountStatusChange) (*Response, error)
+	UnfreezeAccount(context.Context, *AccountStatusChange) (*Response, error)
+	CloseAccount(context.Context, *AccountStatusChange) (*Response, error)
+	FundAccount(context.Context, *FundingRequest) (*DepositResponse, error)
+	SetAccountTier(context.Context, *TierAssignment) (*Response, error)
+	ReverseDeposit(context.Context, *DepositRequest) (*DepositResponse, error)
 	mustEmbedUnimplementedBankServerServer()
 }
 
@@ -876,116 +1995,305 @@ func (UnimplementedBankServerServer) RegisterUser(context.Context, *ClientDetail
 func (UnimplementedBankServerServer) DepositMoney(context.Context, *DepositRequest) (*DepositResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DepositMoney not implemented")
 }
-func (UnimplementedBankServerServer) AbortTransaction(context.Context, *TransactionID) (*Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AbortTransaction not implemented")
+func (UnimplementedBankServerServer) AbortTransaction(context.Context, *TransactionID) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortTransaction not implemented")
+}
+func (UnimplementedBankServerServer) RevokeAccount(context.Context, *ClientDetails) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAccount not implemented")
+}
+func (UnimplementedBankServerServer) ListAccounts(context.Context, *Empty) (*AccountList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedBankServerServer) GetBalance(context.Context, *AccountRequest) (*BalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedBankServerServer) FreezeAccount(context.Context, *AccountStatusChange) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeAccount not implemented")
+}
+func (UnimplementedBankServerServer) UnfreezeAccount(context.Context, *AccountStatusChange) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfreezeAccount not implemented")
+}
+func (UnimplementedBankServerServer) CloseAccount(context.Context, *AccountStatusChange) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseAccount not implemented")
+}
+func (UnimplementedBankServerServer) FundAccount(context.Context, *FundingRequest) (*DepositResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FundAccount not implemented")
+}
+func (UnimplementedBankServerServer) SetAccountTier(context.Context, *TierAssignment) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccountTier not implemented")
+}
+func (UnimplementedBankServerServer) ReverseDeposit(context.Context, *DepositRequest) (*DepositResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseDeposit not implemented")
+}
+func (UnimplementedBankServerServer) mustEmbedUnimplementedBankServerServer() {}
+func (UnimplementedBankServerServer) testEmbeddedByValue()                    {}
+
+// UnsafeBankServerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BankServerServer will
+// result in compilation errors.
+type UnsafeBankServerServer interface {
+	mustEmbedUnimplementedBankServerServer()
+}
+
+func RegisterBankServerServer(s grpc.ServiceRegistrar, srv BankServerServer) {
+	// If the following call pancis, it indicates UnimplementedBankServerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BankServer_ServiceDesc, srv)
+}
+
+func _BankServer_DeductMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServerServer).DeductMoney(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankServer_DeductMoney_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServerServer).DeductMoney(ctx, req.(*DeductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankServer_HasEnoughMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoneyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServerServer).HasEnoughMoney(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankServer_HasEnoughMoney_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServerServer).HasEnoughMoney(ctx, req.(*MoneyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankServer_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientDetails)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServerServer).RegisterUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankServer_RegisterUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServerServer).RegisterUser(ctx, req.(*ClientDetails))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankServer_DepositMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepositRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServerServer).DepositMoney(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankServer_DepositMoney_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServerServer).DepositMoney(ctx, req.(*DepositRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankServer_AbortTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServerServer).AbortTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankServer_AbortTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServerServer).AbortTransaction(ctx, req.(*TransactionID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankServer_RevokeAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientDetails)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServerServer).RevokeAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankServer_RevokeAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServerServer).RevokeAccount(ctx, req.(*ClientDetails))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankServer_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServerServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankServer_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServerServer).ListAccounts(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedBankServerServer) mustEmbedUnimplementedBankServerServer() {}
-func (UnimplementedBankServerServer) testEmbeddedByValue()                    {}
 
-// UnsafeBankServerServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to BankServerServer will
-// result in compilation errors.
-type UnsafeBankServerServer interface {
-	mustEmbedUnimplementedBankServerServer()
+func _BankServer_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServerServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankServer_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServerServer).GetBalance(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterBankServerServer(s grpc.ServiceRegistrar, srv BankServerServer) {
-	// If the following call pancis, it indicates UnimplementedBankServerServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _BankServer_FreezeAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatusChange)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&BankServer_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(BankServerServer).FreezeAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankServer_FreezeAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServerServer).FreezeAccount(ctx, req.(*AccountStatusChange))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _BankServer_DeductMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeductRequest)
+func _BankServer_UnfreezeAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatusChange)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BankServerServer).DeductMoney(ctx, in)
+		return srv.(BankServerServer).UnfreezeAccount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BankServer_DeductMoney_FullMethodName,
+		FullMethod: BankServer_UnfreezeAccount_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BankServerServer).DeductMoney(ctx, req.(*DeductRequest))
+		return srv.(BankServerServer).UnfreezeAccount(ctx, req.(*AccountStatusChange))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BankServer_HasEnoughMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MoneyRequest)
+func _BankServer_CloseAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatusChange)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BankServerServer).HasEnoughMoney(ctx, in)
+		return srv.(BankServerServer).CloseAccount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BankServer_HasEnoughMoney_FullMethodName,
+		FullMethod: BankServer_CloseAccount_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BankServerServer).HasEnoughMoney(ctx, req.(*MoneyRequest))
+		return srv.(BankServerServer).CloseAccount(ctx, req.(*AccountStatusChange))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BankServer_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ClientDetails)
+func _BankServer_FundAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FundingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BankServerServer).RegisterUser(ctx, in)
+		return srv.(BankServerServer).FundAccount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BankServer_RegisterUser_FullMethodName,
+		FullMethod: BankServer_FundAccount_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BankServerServer).RegisterUser(ctx, req.(*ClientDetails))
+		return srv.(BankServerServer).FundAccount(ctx, req.(*FundingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BankServer_DepositMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DepositRequest)
+func _BankServer_SetAccountTier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TierAssignment)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BankServerServer).DepositMoney(ctx, in)
+		return srv.(BankServerServer).SetAccountTier(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BankServer_DepositMoney_FullMethodName,
+		FullMethod: BankServer_SetAccountTier_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BankServerServer).DepositMoney(ctx, req.(*DepositRequest))
+		return srv.(BankServerServer).SetAccountTier(ctx, req.(*TierAssignment))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BankServer_AbortTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransactionID)
+func _BankServer_ReverseDeposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepositRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BankServerServer).AbortTransaction(ctx, in)
+		return srv.(BankServerServer).ReverseDeposit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BankServer_AbortTransaction_FullMethodName,
+		FullMethod: BankServer_ReverseDeposit_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BankServerServer).AbortTransaction(ctx, req.(*TransactionID))
+		return srv.(BankServerServer).ReverseDeposit(ctx, req.(*DepositRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1017,6 +2325,42 @@ var BankServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AbortTransaction",
 			Handler:    _BankServer_AbortTransaction_Handler,
 		},
+		{
+			MethodName: "RevokeAccount",
+			Handler:    _BankServer_RevokeAccount_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _BankServer_ListAccounts_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _BankServer_GetBalance_Handler,
+		},
+		{
+			MethodName: "FreezeAccount",
+			Handler:    _BankServer_FreezeAccount_Handler,
+		},
+		{
+			MethodName: "UnfreezeAccount",
+			Handler:    _BankServer_UnfreezeAccount_Handler,
+		},
+		{
+			MethodName: "CloseAccount",
+			Handler:    _BankServer_CloseAccount_Handler,
+		},
+		{
+			MethodName: "FundAccount",
+			Handler:    _BankServer_FundAccount_Handler,
+		},
+		{
+			MethodName: "SetAccountTier",
+			Handler:    _BankServer_SetAccountTier_Handler,
+		},
+		{
+			MethodName: "ReverseDeposit",
+			Handler:    _BankServer_ReverseDeposit_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "stripe.proto",
@@ -1202,6 +2546,9 @@ var TwoPhaseCommit_ServiceDesc = grpc.ServiceDesc{
 
 const (
 	LoggingService_LogTransaction_FullMethodName = "/stripe.LoggingService/LogTransaction"
+	LoggingService_QueryLogs_FullMethodName      = "/stripe.LoggingService/QueryLogs"
+	LoggingService_VerifyLog_FullMethodName      = "/stripe.LoggingService/VerifyLog"
+	LoggingService_StreamLogs_FullMethodName     = "/stripe.LoggingService/StreamLogs"
 )
 
 // LoggingServiceClient is the client API for LoggingService service.
@@ -1209,6 +2556,9 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LoggingServiceClient interface {
 	LogTransaction(ctx context.Context, in *LogEntry, opts ...grpc.CallOption) (*Response, error)
+	QueryLogs(ctx context.Context, in *LogQuery, opts ...grpc.CallOption) (*LogRecordList, error)
+	VerifyLog(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LogVerification, error)
+	StreamLogs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[LogEntry, LogAck], error)
 }
 
 type loggingServiceClient struct {
@@ -1229,11 +2579,47 @@ func (c *loggingServiceClient) LogTransaction(ctx context.Context, in *LogEntry,
 	return out, nil
 }
 
+func (c *loggingServiceClient) QueryLogs(ctx context.Context, in *LogQuery, opts ...grpc.CallOption) (*LogRecordList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogRecordList)
+	err := c.cc.Invoke(ctx, LoggingService_QueryLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loggingServiceClient) VerifyLog(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LogVerification, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogVerification)
+	err := c.cc.Invoke(ctx, LoggingService_VerifyLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loggingServiceClient) StreamLogs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[LogEntry, LogAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LoggingService_ServiceDesc.Streams[0], LoggingService_StreamLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LogEntry, LogAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LoggingService_StreamLogsClient = grpc.ClientStreamingClient[LogEntry, LogAck]
+
 // LoggingServiceServer is the server API for LoggingService service.
 // All implementations must embed UnimplementedLoggingServiceServer
 // for forward compatibility.
 type LoggingServiceServer interface {
 	LogTransaction(context.Context, *LogEntry) (*Response, error)
+	QueryLogs(context.Context, *LogQuery) (*LogRecordList, error)
+	VerifyLog(context.Context, *Empty) (*LogVerification, error)
+	StreamLogs(grpc.ClientStreamingServer[LogEntry, LogAck]) error
 	mustEmbedUnimplementedLoggingServiceServer()
 }
 
@@ -1247,6 +2633,15 @@ type UnimplementedLoggingServiceServer struct{}
 func (UnimplementedLoggingServiceServer) LogTransaction(context.Context, *LogEntry) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LogTransaction not implemented")
 }
+func (UnimplementedLoggingServiceServer) QueryLogs(context.Context, *LogQuery) (*LogRecordList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryLogs not implemented")
+}
+func (UnimplementedLoggingServiceServer) VerifyLog(context.Context, *Empty) (*LogVerification, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyLog not implemented")
+}
+func (UnimplementedLoggingServiceServer) StreamLogs(grpc.ClientStreamingServer[LogEntry, LogAck]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
 func (UnimplementedLoggingServiceServer) mustEmbedUnimplementedLoggingServiceServer() {}
 func (UnimplementedLoggingServiceServer) testEmbeddedByValue()                        {}
 
@@ -1286,6 +2681,49 @@ func _LoggingService_LogTransaction_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LoggingService_QueryLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoggingServiceServer).QueryLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoggingService_QueryLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoggingServiceServer).QueryLogs(ctx, req.(*LogQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoggingService_VerifyLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoggingServiceServer).VerifyLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoggingService_VerifyLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoggingServiceServer).VerifyLog(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoggingService_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LoggingServiceServer).StreamLogs(&grpc.GenericServerStream[LogEntry, LogAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LoggingService_StreamLogsServer = grpc.ClientStreamingServer[LogEntry, LogAck]
+
 // LoggingService_ServiceDesc is the grpc.ServiceDesc for LoggingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1297,13 +2735,31 @@ var LoggingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LogTransaction",
 			Handler:    _LoggingService_LogTransaction_Handler,
 		},
+		{
+			MethodName: "QueryLogs",
+			Handler:    _LoggingService_QueryLogs_Handler,
+		},
+		{
+			MethodName: "VerifyLog",
+			Handler:    _LoggingService_VerifyLog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _LoggingService_StreamLogs_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "stripe.proto",
 }
 
 const (
 	OfflineQueueService_ProcessQueuedPayments_FullMethodName = "/stripe.OfflineQueueService/ProcessQueuedPayments"
+	OfflineQueueService_ListQueuedPayments_FullMethodName    = "/stripe.OfflineQueueService/ListQueuedPayments"
+	OfflineQueueService_GetQueuedPayment_FullMethodName      = "/stripe.OfflineQueueService/GetQueuedPayment"
+	OfflineQueueService_RequeuePayment_FullMethodName        = "/stripe.OfflineQueueService/RequeuePayment"
+	OfflineQueueService_DiscardPayment_FullMethodName        = "/stripe.OfflineQueueService/DiscardPayment"
 )
 
 // OfflineQueueServiceClient is the client API for OfflineQueueService service.
@@ -1311,6 +2767,10 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OfflineQueueServiceClient interface {
 	ProcessQueuedPayments(ctx context.Context, in *OfflineRequest, opts ...grpc.CallOption) (*Response, error)
+	ListQueuedPayments(ctx context.Context, in *QueueFilter, opts ...grpc.CallOption) (*QueuedPaymentList, error)
+	GetQueuedPayment(ctx context.Context, in *TransactionID, opts ...grpc.CallOption) (*QueuedPayment, error)
+	RequeuePayment(ctx context.Context, in *QueueAction, opts ...grpc.CallOption) (*QueuedPayment, error)
+	DiscardPayment(ctx context.Context, in *QueueAction, opts ...grpc.CallOption) (*Response, error)
 }
 
 type offlineQueueServiceClient struct {
@@ -1331,11 +2791,55 @@ func (c *offlineQueueServiceClient) ProcessQueuedPayments(ctx context.Context, i
 	return out, nil
 }
 
+func (c *offlineQueueServiceClient) ListQueuedPayments(ctx context.Context, in *QueueFilter, opts ...grpc.CallOption) (*QueuedPaymentList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueuedPaymentList)
+	err := c.cc.Invoke(ctx, OfflineQueueService_ListQueuedPayments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *offlineQueueServiceClient) GetQueuedPayment(ctx context.Context, in *TransactionID, opts ...grpc.CallOption) (*QueuedPayment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueuedPayment)
+	err := c.cc.Invoke(ctx, OfflineQueueService_GetQueuedPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *offlineQueueServiceClient) RequeuePayment(ctx context.Context, in *QueueAction, opts ...grpc.CallOption) (*QueuedPayment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueuedPayment)
+	err := c.cc.Invoke(ctx, OfflineQueueService_RequeuePayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *offlineQueueServiceClient) DiscardPayment(ctx context.Context, in *QueueAction, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, OfflineQueueService_DiscardPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OfflineQueueServiceServer is the server API for OfflineQueueService service.
 // All implementations must embed UnimplementedOfflineQueueServiceServer
 // for forward compatibility.
 type OfflineQueueServiceServer interface {
 	ProcessQueuedPayments(context.Context, *OfflineRequest) (*Response, error)
+	ListQueuedPayments(context.Context, *QueueFilter) (*QueuedPaymentList, error)
+	GetQueuedPayment(context.Context, *TransactionID) (*QueuedPayment, error)
+	RequeuePayment(context.Context, *QueueAction) (*QueuedPayment, error)
+	DiscardPayment(context.Context, *QueueAction) (*Response, error)
 	mustEmbedUnimplementedOfflineQueueServiceServer()
 }
 
@@ -1349,6 +2853,18 @@ type UnimplementedOfflineQueueServiceServer struct{}
 func (UnimplementedOfflineQueueServiceServer) ProcessQueuedPayments(context.Context, *OfflineRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProcessQueuedPayments not implemented")
 }
+func (UnimplementedOfflineQueueServiceServer) ListQueuedPayments(context.Context, *QueueFilter) (*QueuedPaymentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQueuedPayments not implemented")
+}
+func (UnimplementedOfflineQueueServiceServer) GetQueuedPayment(context.Context, *TransactionID) (*QueuedPayment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueuedPayment not implemented")
+}
+func (UnimplementedOfflineQueueServiceServer) RequeuePayment(context.Context, *QueueAction) (*QueuedPayment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeuePayment not implemented")
+}
+func (UnimplementedOfflineQueueServiceServer) DiscardPayment(context.Context, *QueueAction) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscardPayment not implemented")
+}
 func (UnimplementedOfflineQueueServiceServer) mustEmbedUnimplementedOfflineQueueServiceServer() {}
 func (UnimplementedOfflineQueueServiceServer) testEmbeddedByValue()                             {}
 
@@ -1388,6 +2904,78 @@ func _OfflineQueueService_ProcessQueuedPayments_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OfflineQueueService_ListQueuedPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OfflineQueueServiceServer).ListQueuedPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OfflineQueueService_ListQueuedPayments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OfflineQueueServiceServer).ListQueuedPayments(ctx, req.(*QueueFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OfflineQueueService_GetQueuedPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OfflineQueueServiceServer).GetQueuedPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OfflineQueueService_GetQueuedPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OfflineQueueServiceServer).GetQueuedPayment(ctx, req.(*TransactionID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OfflineQueueService_RequeuePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueAction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OfflineQueueServiceServer).RequeuePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OfflineQueueService_RequeuePayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OfflineQueueServiceServer).RequeuePayment(ctx, req.(*QueueAction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OfflineQueueService_DiscardPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueAction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OfflineQueueServiceServer).DiscardPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OfflineQueueService_DiscardPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OfflineQueueServiceServer).DiscardPayment(ctx, req.(*QueueAction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OfflineQueueService_ServiceDesc is the grpc.ServiceDesc for OfflineQueueService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1399,6 +2987,22 @@ var OfflineQueueService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ProcessQueuedPayments",
 			Handler:    _OfflineQueueService_ProcessQueuedPayments_Handler,
 		},
+		{
+			MethodName: "ListQueuedPayments",
+			Handler:    _OfflineQueueService_ListQueuedPayments_Handler,
+		},
+		{
+			MethodName: "GetQueuedPayment",
+			Handler:    _OfflineQueueService_GetQueuedPayment_Handler,
+		},
+		{
+			MethodName: "RequeuePayment",
+			Handler:    _OfflineQueueService_RequeuePayment_Handler,
+		},
+		{
+			MethodName: "DiscardPayment",
+			Handler:    _OfflineQueueService_DiscardPayment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "stripe.proto",
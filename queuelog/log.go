@@ -0,0 +1,355 @@
+package queuelog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// returned when an offset is not (or no longer) in the queue
+var ErrNotFound = errors.New("offset is not pending in the queue log")
+
+// biggest active segment before a new one is started, older segments get compacted
+const DefaultSegmentSize = 1 << 20
+
+// one item still waiting for its ack
+type Entry struct {
+	Offset  uint64
+	Payload []byte
+}
+
+// durable queue kept as an append-only log split in segment files
+//
+// an item is an enqueue record, updates and acking it append more records, so a crash at
+// any point replays into exactly the items that were enqueued and not yet acked, each with
+// its last payload. segments other than the active one are rewritten by Compact down to
+// one enqueue record per live item carrying its current payload.
+type Log struct {
+	mu          sync.Mutex
+	dir         string
+	segmentSize int64
+
+	//closed segments, oldest first, then the active one
+	segments   []segment
+	active     *os.File
+	activeSize int64
+	//records in each segment by id, to see which ones compaction would shrink
+	records map[uint64]int
+	//segments by id with an item updated from a later segment, the payload they hold is old
+	updated map[uint64]bool
+
+	nextOffset uint64
+	pending    map[uint64][]byte
+}
+
+// function to open the log in dir, creating it if needed, and replay it
+// segmentSize <= 0 means DefaultSegmentSize
+func Open(dir string, segmentSize int64) (*Log, error) {
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create queue log dir: %v", err)
+	}
+
+	//rewrites that never got renamed in place are the old segment's leftovers
+	leftovers, _ := filepath.Glob(filepath.Join(dir, "*"+segmentSuffix+".compact"))
+	for _, path := range leftovers {
+		os.Remove(path)
+	}
+
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list queue log segments: %v", err)
+	}
+
+	l := &Log{
+		dir:         dir,
+		segmentSize: segmentSize,
+		segments:    segments,
+		records:     make(map[uint64]int),
+		updated:     make(map[uint64]bool),
+		pending:     make(map[uint64][]byte),
+	}
+
+	var good int64
+	for i, seg := range segments {
+		if seg.base > l.nextOffset {
+			l.nextOffset = seg.base
+		}
+		good, err = readSegment(seg.path, func(rec record) {
+			l.apply(seg.id, rec)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read queue log segment %s: %v", seg.path, err)
+		}
+
+		//only the active segment can have a torn tail, closed ones were synced before rolling
+		info, err := os.Stat(seg.path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read queue log segment %s: %v", seg.path, err)
+		}
+		if good != info.Size() && i != len(segments)-1 {
+			return nil, fmt.Errorf("Corrupt queue log segment %s at byte %d", seg.path, good)
+		}
+	}
+
+	if len(segments) == 0 {
+		err = l.startSegment()
+		if err != nil {
+			return nil, err
+		}
+		return l, nil
+	}
+
+	//cut a torn last record from a crash mid write, else the next record would follow garbage
+	last := segments[len(segments)-1]
+	file, err := os.OpenFile(last.path, os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open queue log segment: %v", err)
+	}
+	err = file.Truncate(good)
+	if err == nil {
+		_, err = file.Seek(good, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("Failed to repair queue log segment: %v", err)
+	}
+	l.active = file
+	l.activeSize = good
+	return l, nil
+}
+
+// function to apply one record read back or just written to segment id, caller holds mu
+func (l *Log) apply(id uint64, rec record) {
+	l.records[id]++
+	switch rec.kind {
+	case recordEnqueue:
+		l.pending[rec.offset] = rec.payload
+		if rec.offset >= l.nextOffset {
+			l.nextOffset = rec.offset + 1
+		}
+	case recordUpdate:
+		_, exists := l.pending[rec.offset]
+		if exists {
+			l.pending[rec.offset] = rec.payload
+			if enqueuedIn, ok := l.segmentOf(rec.offset); ok && enqueuedIn != id {
+				l.updated[enqueuedIn] = true
+			}
+		}
+	case recordAck:
+		delete(l.pending, rec.offset)
+	}
+}
+
+// function to write one record to the active segment and sync it, caller holds mu
+func (l *Log) write(rec record) error {
+	data := encodeRecord(rec)
+	_, err := l.active.Write(data)
+	if err != nil {
+		return fmt.Errorf("Failed to write queue log: %v", err)
+	}
+	err = l.active.Sync()
+	if err != nil {
+		return fmt.Errorf("Failed to sync queue log: %v", err)
+	}
+	l.activeSize += int64(len(data))
+	l.apply(l.segments[len(l.segments)-1].id, rec)
+
+	if l.activeSize < l.segmentSize {
+		return nil
+	}
+	//a full segment is closed and the closed ones are compacted, failures here lose nothing
+	if l.startSegment() == nil {
+		l.compactLocked()
+	}
+	return nil
+}
+
+// function to close the active segment and start a new one at the next offset, caller holds mu
+func (l *Log) startSegment() error {
+	seg := segment{base: l.nextOffset}
+	if len(l.segments) > 0 {
+		seg.id = l.segments[len(l.segments)-1].id + 1
+	}
+	seg.path = segmentPath(l.dir, seg.base, seg.id)
+	file, err := os.OpenFile(seg.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to create queue log segment: %v", err)
+	}
+	err = syncDir(l.dir)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("Failed to sync queue log dir: %v", err)
+	}
+
+	if l.active != nil {
+		l.active.Close()
+	}
+	l.active = file
+	l.activeSize = 0
+	l.segments = append(l.segments, seg)
+	return nil
+}
+
+// function to add an item, it is durable once this returns
+func (l *Log) Append(payload []byte) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	offset := l.nextOffset
+	err := l.write(record{kind: recordEnqueue, offset: offset, payload: payload})
+	if err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// function to remove an item for good, it never comes back from a replay once this returns
+func (l *Log) Ack(offset uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, exists := l.pending[offset]
+	if !exists {
+		return ErrNotFound
+	}
+	return l.write(record{kind: recordAck, offset: offset})
+}
+
+// function to replace the payload of an item, e.g. to keep its retry state
+func (l *Log) Update(offset uint64, payload []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, exists := l.pending[offset]
+	if !exists {
+		return ErrNotFound
+	}
+	return l.write(record{kind: recordUpdate, offset: offset, payload: payload})
+}
+
+// function to list items not acked yet, in offset order
+func (l *Log) Pending() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := make([]Entry, 0, len(l.pending))
+	for offset, payload := range l.pending {
+		entries = append(entries, Entry{Offset: offset, Payload: payload})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Offset < entries[j].Offset })
+	return entries
+}
+
+// function to rewrite closed segments without acked items, empty ones are deleted
+func (l *Log) Compact() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.compactLocked()
+}
+
+// caller holds mu
+// segments go oldest first: acks and updates always sit in the same or a later segment than
+// their item, so when a crash stops us halfway no dropped ack can bring back an item and
+// no dropped update can roll one back
+func (l *Log) compactLocked() error {
+	active := len(l.segments) - 1
+	live := l.liveBySegment()
+
+	kept := make([]segment, 0, len(l.segments))
+	for i, seg := range l.segments {
+		//the active segment is still being written to, only closed ones are rewritten
+		if i == active || (l.records[seg.id] == len(live[seg.id]) && !l.updated[seg.id]) {
+			kept = append(kept, seg)
+			continue
+		}
+
+		if len(live[seg.id]) == 0 {
+			err := os.Remove(seg.path)
+			if err != nil {
+				l.segments = append(kept, l.segments[i:]...)
+				return fmt.Errorf("Failed to remove queue log segment: %v", err)
+			}
+			delete(l.records, seg.id)
+			delete(l.updated, seg.id)
+			continue
+		}
+
+		err := l.rewrite(seg, live[seg.id])
+		if err != nil {
+			l.segments = append(kept, l.segments[i:]...)
+			return err
+		}
+		l.records[seg.id] = len(live[seg.id])
+		delete(l.updated, seg.id)
+		kept = append(kept, seg)
+	}
+	l.segments = kept
+	return syncDir(l.dir)
+}
+
+// function to group pending offsets by the id of the segment they were written to, caller holds mu
+func (l *Log) liveBySegment() map[uint64][]uint64 {
+	live := make(map[uint64][]uint64)
+	for offset := range l.pending {
+		id, ok := l.segmentOf(offset)
+		if ok {
+			live[id] = append(live[id], offset)
+		}
+	}
+	for _, offsets := range live {
+		sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	}
+	return live
+}
+
+// function to find the id of the segment holding the enqueue of an offset, caller holds mu
+func (l *Log) segmentOf(offset uint64) (uint64, bool) {
+	//last segment whose base is not after the offset, of segments sharing a base only
+	//the last one can hold enqueues as the others were rolled before any came in
+	i := sort.Search(len(l.segments), func(i int) bool { return l.segments[i].base > offset }) - 1
+	if i < 0 {
+		return 0, false
+	}
+	return l.segments[i].id, true
+}
+
+// function to replace a segment with one holding only the given items, atomically by rename
+func (l *Log) rewrite(seg segment, offsets []uint64) error {
+	tmpPath := seg.path + ".compact"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to compact queue log: %v", err)
+	}
+
+	var data []byte
+	for _, offset := range offsets {
+		data = append(data, encodeRecord(record{kind: recordEnqueue, offset: offset, payload: l.pending[offset]})...)
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	file.Close()
+	if err == nil {
+		err = os.Rename(tmpPath, seg.path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("Failed to compact queue log: %v", err)
+	}
+	return nil
+}
+
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.active.Close()
+}
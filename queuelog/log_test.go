@@ -0,0 +1,280 @@
+package queuelog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// function to read the pending items as offset : payload
+func pendingOf(l *Log) map[uint64]string {
+	items := make(map[uint64]string)
+	for _, entry := range l.Pending() {
+		items[entry.Offset] = string(entry.Payload)
+	}
+	return items
+}
+
+func mustAppend(t *testing.T, l *Log, payload string) uint64 {
+	t.Helper()
+	offset, err := l.Append([]byte(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return offset
+}
+
+func mustUpdate(t *testing.T, l *Log, offset uint64, payload string) {
+	t.Helper()
+	if err := l.Update(offset, []byte(payload)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// function to close the log and open it again from its files
+func reopen(t *testing.T, l *Log, dir string, segmentSize int64) *Log {
+	t.Helper()
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := Open(dir, segmentSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reopened
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+segmentSuffix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestRestartKeepsPendingItems(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Open(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := mustAppend(t, l, "a")
+	b := mustAppend(t, l, "b")
+	c := mustAppend(t, l, "c")
+	mustUpdate(t, l, b, "b2")
+	if err := l.Ack(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Ack(a); err != ErrNotFound {
+		t.Fatalf("second ack: got %v, want ErrNotFound", err)
+	}
+
+	l = reopen(t, l, dir, 0)
+	defer l.Close()
+	want := map[uint64]string{b: "b2", c: "c"}
+	if got := pendingOf(l); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after restart got %v, want %v", got, want)
+	}
+
+	//offsets keep counting from where the log left off
+	if d := mustAppend(t, l, "d"); d != c+1 {
+		t.Fatalf("offset after restart %d, want %d", d, c+1)
+	}
+}
+
+func TestRestartCutsTornTail(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Open(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := mustAppend(t, l, "a")
+	l.Close()
+
+	//a crash half way through the next record
+	files := segmentFiles(t, dir)
+	file, err := os.OpenFile(files[len(files)-1], os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write(encodeRecord(record{kind: recordEnqueue, offset: a + 1, payload: []byte("torn")})[:10])
+	file.Close()
+
+	l, err = Open(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := mustAppend(t, l, "b")
+	l = reopen(t, l, dir, 0)
+	defer l.Close()
+
+	want := map[uint64]string{a: "a", b: "b"}
+	if got := pendingOf(l); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after torn tail got %v, want %v", got, want)
+	}
+}
+
+func TestCompactionDropsAckedItems(t *testing.T) {
+	dir := t.TempDir()
+	const segmentSize = 100
+	l, err := Open(dir, segmentSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := make(map[uint64]string)
+	for i := 0; i < 50; i++ {
+		payload := fmt.Sprintf("item-%02d", i)
+		offset := mustAppend(t, l, payload)
+		if i%10 == 0 {
+			want[offset] = payload
+			continue
+		}
+		if err := l.Ack(offset); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Compact(); err != nil {
+		t.Fatal(err)
+	}
+
+	//every closed segment left holds a live item
+	if files := segmentFiles(t, dir); len(files) > len(want)+1 {
+		t.Fatalf("%d segments left after compaction, want at most %d", len(files), len(want)+1)
+	}
+	if got := pendingOf(l); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after compaction got %v, want %v", got, want)
+	}
+
+	l = reopen(t, l, dir, segmentSize)
+	defer l.Close()
+	if got := pendingOf(l); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after compaction and restart got %v, want %v", got, want)
+	}
+}
+
+// rolls with only acks in between used to give the new segment the name of the active one
+func TestRollOnAcksKeepsItems(t *testing.T) {
+	dir := t.TempDir()
+	const segmentSize = 200
+	l, err := Open(dir, segmentSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var offsets []uint64
+	for i := 0; i < 40; i++ {
+		offsets = append(offsets, mustAppend(t, l, fmt.Sprintf("item-%02d", i)))
+	}
+	//the acks fill several segments without a new item to name them after
+	for _, offset := range offsets[:39] {
+		if err := l.Ack(offset); err != nil {
+			t.Fatal(err)
+		}
+	}
+	last := mustAppend(t, l, "item-last")
+
+	want := map[uint64]string{offsets[39]: "item-39", last: "item-last"}
+	if got := pendingOf(l); !reflect.DeepEqual(got, want) {
+		t.Fatalf("before restart got %v, want %v", got, want)
+	}
+
+	l = reopen(t, l, dir, segmentSize)
+	defer l.Close()
+	if got := pendingOf(l); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after restart got %v, want %v", got, want)
+	}
+}
+
+// rolls with only updates in between used to give the new segment the name of the active one
+func TestRollWithoutAppendKeepsItems(t *testing.T) {
+	dir := t.TempDir()
+	const segmentSize = 200
+	l, err := Open(dir, segmentSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := mustAppend(t, l, "item-A")
+	b := mustAppend(t, l, "item-B")
+	for attempt := 1; attempt <= 20; attempt++ {
+		mustUpdate(t, l, a, fmt.Sprintf("item-A-attempt-%02d", attempt))
+	}
+	c := mustAppend(t, l, "item-C")
+
+	want := map[uint64]string{a: "item-A-attempt-20", b: "item-B", c: "item-C"}
+	if got := pendingOf(l); !reflect.DeepEqual(got, want) {
+		t.Fatalf("before restart got %v, want %v", got, want)
+	}
+
+	l = reopen(t, l, dir, segmentSize)
+	defer l.Close()
+	if got := pendingOf(l); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after restart got %v, want %v", got, want)
+	}
+}
+
+// an update in a later segment must not be compacted away while the item's own segment keeps the old payload
+func TestCompactionKeepsUpdatesOfOlderSegments(t *testing.T) {
+	dir := t.TempDir()
+	const segmentSize = 100
+	l, err := Open(dir, segmentSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var offsets []uint64
+	for i := 0; i < 5; i++ {
+		offsets = append(offsets, mustAppend(t, l, fmt.Sprintf("item-%d", i)))
+	}
+	want := make(map[uint64]string)
+	for round := 1; round <= 5; round++ {
+		for i, offset := range offsets {
+			payload := fmt.Sprintf("item-%d-round-%d", i, round)
+			mustUpdate(t, l, offset, payload)
+			want[offset] = payload
+		}
+	}
+	//one more item so the update only segments get closed and compacted
+	want[mustAppend(t, l, "last")] = "last"
+	if err := l.Compact(); err != nil {
+		t.Fatal(err)
+	}
+
+	l = reopen(t, l, dir, segmentSize)
+	defer l.Close()
+	if got := pendingOf(l); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after compaction and restart got %v, want %v", got, want)
+	}
+}
+
+func TestSegmentsWithoutIdStillLoad(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Open(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := mustAppend(t, l, "a")
+	l.Close()
+
+	//rename to how segments were named before they had an id
+	files := segmentFiles(t, dir)
+	if err := os.Rename(files[0], filepath.Join(dir, fmt.Sprintf("%020d%s", 0, segmentSuffix))); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err = Open(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	b := mustAppend(t, l, "b")
+	want := map[uint64]string{a: "a", b: "b"}
+	if got := pendingOf(l); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package queuelog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// kinds of records in a segment
+const (
+	recordEnqueue byte = 1
+	recordAck     byte = 2
+	//new payload of an item still in the queue
+	recordUpdate byte = 3
+)
+
+// every record is framed as
+//
+//	length uint32 | crc32 uint32 | kind byte | offset uint64 | payload
+//
+// length and crc cover kind, offset and payload, a record failing either is a torn write
+const frameHeaderSize = 8
+const recordHeaderSize = 1 + 8
+
+// refuse absurd lengths from a corrupt header instead of allocating them
+const maxRecordSize = 16 << 20
+
+const segmentSuffix = ".log"
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+var errTornRecord = errors.New("torn record")
+
+type record struct {
+	kind    byte
+	offset  uint64
+	payload []byte
+}
+
+// function to encode one record with its frame
+func encodeRecord(rec record) []byte {
+	body := make([]byte, recordHeaderSize+len(rec.payload))
+	body[0] = rec.kind
+	binary.BigEndian.PutUint64(body[1:9], rec.offset)
+	copy(body[recordHeaderSize:], rec.payload)
+
+	frame := make([]byte, frameHeaderSize, frameHeaderSize+len(body))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(len(body)))
+	binary.BigEndian.PutUint32(frame[4:8], crc32.Checksum(body, crcTable))
+	return append(frame, body...)
+}
+
+// function to read the next record, errTornRecord means the rest of the file is garbage
+func readRecord(reader *bufio.Reader) (record, int64, error) {
+	var header [frameHeaderSize]byte
+	_, err := io.ReadFull(reader, header[:])
+	if err == io.EOF {
+		return record{}, 0, io.EOF
+	}
+	if err != nil {
+		return record{}, 0, errTornRecord
+	}
+
+	length := binary.BigEndian.Uint32(header[0:4])
+	if length < recordHeaderSize || length > maxRecordSize {
+		return record{}, 0, errTornRecord
+	}
+	body := make([]byte, length)
+	_, err = io.ReadFull(reader, body)
+	if err != nil {
+		return record{}, 0, errTornRecord
+	}
+	if crc32.Checksum(body, crcTable) != binary.BigEndian.Uint32(header[4:8]) {
+		return record{}, 0, errTornRecord
+	}
+
+	rec := record{
+		kind:    body[0],
+		offset:  binary.BigEndian.Uint64(body[1:9]),
+		payload: body[recordHeaderSize:],
+	}
+	return rec, int64(frameHeaderSize + length), nil
+}
+
+// one file of the log, named after the first offset it was opened with and its place in the log
+// segments rolled with no enqueue in between share a base, id keeps their names apart
+type segment struct {
+	base uint64
+	id   uint64
+	path string
+}
+
+func segmentPath(dir string, base, id uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d-%020d%s", base, id, segmentSuffix))
+}
+
+// function to list the segments of dir, oldest first
+// segments named by base alone come from before ids were added and count as id 0
+func listSegments(dir string) ([]segment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []segment
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		baseText, idText, hasId := strings.Cut(strings.TrimSuffix(name, segmentSuffix), "-")
+		base, err := strconv.ParseUint(baseText, 10, 64)
+		if err != nil {
+			continue
+		}
+		var id uint64
+		if hasId {
+			id, err = strconv.ParseUint(idText, 10, 64)
+			if err != nil {
+				continue
+			}
+		}
+		segments = append(segments, segment{base: base, id: id, path: filepath.Join(dir, name)})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		if segments[i].base != segments[j].base {
+			return segments[i].base < segments[j].base
+		}
+		return segments[i].id < segments[j].id
+	})
+	return segments, nil
+}
+
+// function to read every intact record of a segment, returns the size of the intact part
+func readSegment(path string, visit func(record)) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var good int64
+	for {
+		rec, size, err := readRecord(reader)
+		if err == io.EOF || err == errTornRecord {
+			return good, nil
+		}
+		visit(rec)
+		good += size
+	}
+}
+
+// function to sync a directory so creates, renames and removes in it survive a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	authee "assignment_2/auth"
+	pb "assignment_2/proto"
+	"assignment_2/restapi"
+	"assignment_2/tlsutil"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+var paymentGatewayForRest = "localhost:50052"
+var authLoadBalancerAddressForRest = "localhost:50055"
+var bankLoadBalancerAddressForRest = "localhost:50056"
+
+// tls only, no service token: the caller's own bearer token or api key is forwarded so every
+// service checks the real caller, the rest gateway never calls with more rights than its client
+var transportSecurity = grpc.WithTransportCredentials(tlsutil.ClientCredentials(authee.ServiceRestGateway))
+
+// grpc connections of the rest gateway, one per address and reused across requests
+type grpcClients struct {
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+	//dialed once at startup, its address never changes
+	gateway pb.PaymentGatewayClient
+}
+
+// function to get the connection to an address, dialing it the first time
+func (gc *grpcClients) connection(address string) (*grpc.ClientConn, error) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	conn, ok := gc.conns[address]
+	if ok {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(address, transportSecurity)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect to %s: %v", address, err)
+	}
+	gc.conns[address] = conn
+	return conn, nil
+}
+
+func (gc *grpcClients) PaymentGateway() pb.PaymentGatewayClient {
+	return gc.gateway
+}
+
+// function to get an auth server from the auth load balancer
+func (gc *grpcClients) Authentication(ctx context.Context) (pb.AuthenticationClient, error) {
+	lbConn, err := gc.connection(authLoadBalancerAddressForRest)
+	if err != nil {
+		return nil, err
+	}
+	serverInfo, err := pb.NewAuthLoadBalancerClient(lbConn).GetAuthServer(ctx, &pb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	conn, err := gc.connection(serverInfo.Address)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewAuthenticationClient(conn), nil
+}
+
+// function to get the bank server owning an account from the bank load balancer
+func (gc *grpcClients) BankFor(ctx context.Context, accountNumber string) (pb.BankServerClient, error) {
+	lbConn, err := gc.connection(bankLoadBalancerAddressForRest)
+	if err != nil {
+		return nil, err
+	}
+	shard, err := pb.NewBankLoadBalancerClient(lbConn).GetAccountShard(ctx, &pb.AccountRequest{AccountNumber: accountNumber})
+	if err != nil {
+		return nil, err
+	}
+	conn, err := gc.connection(shard.Address)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewBankServerClient(conn), nil
+}
+
+func main() {
+	//refuse to start when the published spec drifted from the routes or Stripe.proto
+	problems := restapi.CheckSpec(restapi.OpenAPISpec, restapi.Routes())
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Println("openapi:", problem)
+		}
+		log.Fatalf("openapi.yaml does not match the routes, run go run openapi_check.go")
+	}
+
+	address := os.Getenv("REST_ADDR")
+	if address == "" {
+		address = ":8080"
+	}
+
+	//plain server tls, http clients like curl don't carry a client certificate
+	config := tlsutil.FromEnv(authee.ServiceRestGateway)
+	config.Mutual = false
+	tlsConfig, err := config.ServerTLSConfig()
+	if err != nil {
+		log.Fatalf("Failed to load tls for rest gateway: %v", err)
+	}
+	tlsConfig.NextProtos = []string{"h2", "http/1.1"}
+
+	clients := &grpcClients{conns: make(map[string]*grpc.ClientConn)}
+	gatewayConn, err := clients.connection(paymentGatewayForRest)
+	if err != nil {
+		log.Fatalf("Failed to create payment gateway client: %v", err)
+	}
+	clients.gateway = pb.NewPaymentGatewayClient(gatewayConn)
+
+	server := &http.Server{
+		Addr:              address,
+		Handler:           restapi.NewServer(clients).Handler(),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Println("REST gateway running on", address)
+	err = server.ListenAndServeTLS("", "")
+	if err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
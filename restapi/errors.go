@@ -0,0 +1,107 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// body of every error answer
+//
+//	{"error": {"code": "PERMISSION_DENIED", "message": "...", "reason": "MISSING_SCOPE", "metadata": {...}}}
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	//grpc code name, stable for clients to switch on
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	//from the ErrorInfo detail when the service sent one
+	Reason   string            `json:"reason,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// grpc code : http status, same mapping as google's http annotations
+var httpStatusOf = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// function to turn a grpc error into the error json
+func writeGRPCError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	detail := errorDetail{Code: codeName(st.Code()), Message: st.Message()}
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if ok {
+			detail.Reason = info.Reason
+			detail.Metadata = info.Metadata
+			break
+		}
+	}
+
+	httpStatus, known := httpStatusOf[st.Code()]
+	if !known {
+		httpStatus = http.StatusInternalServerError
+	}
+	writeJSON(w, httpStatus, errorBody{Error: detail})
+}
+
+// function to answer with an error made up by the rest layer itself
+func writeError(w http.ResponseWriter, code codes.Code, message string) {
+	writeGRPCError(w, status.Error(code, message))
+}
+
+// function to get names like NOT_FOUND instead of NotFound
+func codeName(code codes.Code) string {
+	name, known := codeNames[code]
+	if !known {
+		return "UNKNOWN"
+	}
+	return name
+}
+
+var codeNames = map[codes.Code]string{
+	codes.OK:                 "OK",
+	codes.Canceled:           "CANCELLED",
+	codes.Unknown:            "UNKNOWN",
+	codes.InvalidArgument:    "INVALID_ARGUMENT",
+	codes.DeadlineExceeded:   "DEADLINE_EXCEEDED",
+	codes.NotFound:           "NOT_FOUND",
+	codes.AlreadyExists:      "ALREADY_EXISTS",
+	codes.PermissionDenied:   "PERMISSION_DENIED",
+	codes.ResourceExhausted:  "RESOURCE_EXHAUSTED",
+	codes.FailedPrecondition: "FAILED_PRECONDITION",
+	codes.Aborted:            "ABORTED",
+	codes.OutOfRange:         "OUT_OF_RANGE",
+	codes.Unimplemented:      "UNIMPLEMENTED",
+	codes.Internal:           "INTERNAL",
+	codes.Unavailable:        "UNAVAILABLE",
+	codes.DataLoss:           "DATA_LOSS",
+	codes.Unauthenticated:    "UNAUTHENTICATED",
+}
+
+func writeJSON(w http.ResponseWriter, httpStatus int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	json.NewEncoder(w).Encode(body)
+}
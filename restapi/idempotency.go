@@ -0,0 +1,153 @@
+package restapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// header clients set to make a POST safe to retry
+const idempotencyHeader = "Idempotency-Key"
+
+// how long an answer is kept for replay
+const idempotencyLifetime = 24 * time.Hour
+
+// how often expired answers are swept out
+const idempotencySweepInterval = time.Minute
+
+// biggest body we read, payments are tiny
+const maxBodySize = 1 << 20
+
+// answer stored under one idempotency key
+type storedResponse struct {
+	//sha256 of the request body, reusing a key for a different request is an error
+	requestHash string
+	done        bool
+	status      int
+	contentType string
+	body        []byte
+	expiry      time.Time
+}
+
+// remembers answers of POST requests by idempotency key
+type idempotencyStore struct {
+	mu        sync.Mutex
+	responses map[string]*storedResponse
+	lastSweep time.Time
+}
+
+func newIdempotencyStore() *idempotencyStore {
+	return &idempotencyStore{responses: make(map[string]*storedResponse)}
+}
+
+func hashOf(parts ...string) string {
+	sum := sha256.New()
+	for _, part := range parts {
+		sum.Write([]byte(part))
+		sum.Write([]byte{0})
+	}
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// records what the wrapped handler writes
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(status int) {
+	rr.status = status
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+func (rr *responseRecorder) Write(data []byte) (int, error) {
+	if rr.status == 0 {
+		rr.status = http.StatusOK
+	}
+	rr.body.Write(data)
+	return rr.ResponseWriter.Write(data)
+}
+
+// function to wrap a POST handler, a retried request with the same key gets the first answer again
+// keys are scoped to the caller's credentials so two clients never see each other's answers
+func (store *idempotencyStore) wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			writeError(w, codes.InvalidArgument, "Failed to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := hashOf(r.Header.Get("Authorization"), r.Header.Get("X-Api-Key"), r.Method, r.URL.Path, key)
+		requestHash := hashOf(string(body))
+
+		store.mu.Lock()
+		store.dropExpiredLocked()
+		stored, exists := store.responses[scope]
+		if exists && stored.done && time.Now().After(stored.expiry) {
+			delete(store.responses, scope)
+			exists = false
+		}
+		if exists {
+			store.mu.Unlock()
+			switch {
+			case stored.requestHash != requestHash:
+				writeError(w, codes.InvalidArgument, "Idempotency-Key was already used for a different request")
+			case !stored.done:
+				writeError(w, codes.Aborted, "A request with this Idempotency-Key is still in progress")
+			default:
+				w.Header().Set("Content-Type", stored.contentType)
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(stored.status)
+				w.Write(stored.body)
+			}
+			return
+		}
+		stored = &storedResponse{requestHash: requestHash, expiry: time.Now().Add(idempotencyLifetime)}
+		store.responses[scope] = stored
+		store.mu.Unlock()
+
+		recorder := &responseRecorder{ResponseWriter: w}
+		next(recorder, r)
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		//server side failures are not kept, the client should be able to retry them
+		if recorder.status >= 500 {
+			delete(store.responses, scope)
+			return
+		}
+		stored.done = true
+		stored.status = recorder.status
+		stored.contentType = w.Header().Get("Content-Type")
+		stored.body = recorder.body.Bytes()
+	}
+}
+
+// caller holds mu
+func (store *idempotencyStore) dropExpiredLocked() {
+	now := time.Now()
+	if now.Sub(store.lastSweep) < idempotencySweepInterval {
+		return
+	}
+	store.lastSweep = now
+	for scope, stored := range store.responses {
+		if stored.done && now.After(stored.expiry) {
+			delete(store.responses, scope)
+		}
+	}
+}
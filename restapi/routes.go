@@ -0,0 +1,132 @@
+package restapi
+
+import (
+	pb "assignment_2/proto"
+	"context"
+	"net/http"
+)
+
+// every endpoint of the rest api, each one listed in openapi.yaml under the same x-grpc-method
+func Routes() []Route {
+	return []Route{
+		//authentication
+		route(http.MethodPost, "/v1/users", pb.Authentication_Register_FullMethodName,
+			func() *pb.ClientDetails { return &pb.ClientDetails{} },
+			func(ctx context.Context, c Clients, req *pb.ClientDetails) (*pb.Response, error) {
+				auth, err := c.Authentication(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return auth.Register(ctx, req)
+			}),
+		route(http.MethodPost, "/v1/sessions", pb.Authentication_Login_FullMethodName,
+			func() *pb.Credentials { return &pb.Credentials{} },
+			func(ctx context.Context, c Clients, req *pb.Credentials) (*pb.AuthToken, error) {
+				auth, err := c.Authentication(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return auth.Login(ctx, req)
+			}),
+		route(http.MethodPost, "/v1/sessions/totp", pb.Authentication_VerifyTOTP_FullMethodName,
+			func() *pb.TOTPVerification { return &pb.TOTPVerification{} },
+			func(ctx context.Context, c Clients, req *pb.TOTPVerification) (*pb.AuthToken, error) {
+				auth, err := c.Authentication(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return auth.VerifyTOTP(ctx, req)
+			}),
+		route(http.MethodPost, "/v1/totp", pb.Authentication_EnrollTOTP_FullMethodName,
+			func() *pb.Empty { return &pb.Empty{} },
+			func(ctx context.Context, c Clients, req *pb.Empty) (*pb.TOTPEnrollment, error) {
+				auth, err := c.Authentication(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return auth.EnrollTOTP(ctx, req)
+			}),
+		route(http.MethodPost, "/v1/totp/confirm", pb.Authentication_ConfirmTOTP_FullMethodName,
+			func() *pb.TOTPCode { return &pb.TOTPCode{} },
+			func(ctx context.Context, c Clients, req *pb.TOTPCode) (*pb.RecoveryCodes, error) {
+				auth, err := c.Authentication(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return auth.ConfirmTOTP(ctx, req)
+			}),
+		route(http.MethodPost, "/v1/users/{username}/role", pb.Authentication_AssignRole_FullMethodName,
+			func() *pb.RoleAssignment { return &pb.RoleAssignment{} },
+			func(ctx context.Context, c Clients, req *pb.RoleAssignment) (*pb.Response, error) {
+				auth, err := c.Authentication(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return auth.AssignRole(ctx, req)
+			}),
+		route(http.MethodPost, "/v1/users/{username}/tier", pb.Authentication_AssignTier_FullMethodName,
+			func() *pb.TierAssignment { return &pb.TierAssignment{} },
+			func(ctx context.Context, c Clients, req *pb.TierAssignment) (*pb.Response, error) {
+				auth, err := c.Authentication(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return auth.AssignTier(ctx, req)
+			}),
+
+		//payments
+		route(http.MethodPost, "/v1/payments", pb.PaymentGateway_InitiateTransaction_FullMethodName,
+			func() *pb.TransactionRequest { return &pb.TransactionRequest{} },
+			func(ctx context.Context, c Clients, req *pb.TransactionRequest) (*pb.TransactionResponse, error) {
+				return c.PaymentGateway().InitiateTransaction(ctx, req)
+			}),
+		route(http.MethodGet, "/v1/payments/{transactionId}", pb.PaymentGateway_GetTransactionStatus_FullMethodName,
+			func() *pb.TransactionID { return &pb.TransactionID{} },
+			func(ctx context.Context, c Clients, req *pb.TransactionID) (*pb.TransactionStatus, error) {
+				return c.PaymentGateway().GetTransactionStatus(ctx, req)
+			}),
+		route(http.MethodPost, "/v1/payments/{transactionId}/confirm", pb.PaymentGateway_ConfirmTransaction_FullMethodName,
+			func() *pb.TransactionConfirmation { return &pb.TransactionConfirmation{} },
+			func(ctx context.Context, c Clients, req *pb.TransactionConfirmation) (*pb.Response, error) {
+				return c.PaymentGateway().ConfirmTransaction(ctx, req)
+			}),
+
+		//accounts, routed to the bank server owning the account
+		route(http.MethodGet, "/v1/accounts/{accountNumber}/balance", pb.BankServer_GetBalance_FullMethodName,
+			func() *pb.AccountRequest { return &pb.AccountRequest{} },
+			func(ctx context.Context, c Clients, req *pb.AccountRequest) (*pb.BalanceResponse, error) {
+				bank, err := c.BankFor(ctx, req.AccountNumber)
+				if err != nil {
+					return nil, err
+				}
+				return bank.GetBalance(ctx, req)
+			}),
+		route(http.MethodPost, "/v1/accounts/{accountNumber}/freeze", pb.BankServer_FreezeAccount_FullMethodName,
+			func() *pb.AccountStatusChange { return &pb.AccountStatusChange{} },
+			func(ctx context.Context, c Clients, req *pb.AccountStatusChange) (*pb.Response, error) {
+				bank, err := c.BankFor(ctx, req.AccountNumber)
+				if err != nil {
+					return nil, err
+				}
+				return bank.FreezeAccount(ctx, req)
+			}),
+		route(http.MethodPost, "/v1/accounts/{accountNumber}/unfreeze", pb.BankServer_UnfreezeAccount_FullMethodName,
+			func() *pb.AccountStatusChange { return &pb.AccountStatusChange{} },
+			func(ctx context.Context, c Clients, req *pb.AccountStatusChange) (*pb.Response, error) {
+				bank, err := c.BankFor(ctx, req.AccountNumber)
+				if err != nil {
+					return nil, err
+				}
+				return bank.UnfreezeAccount(ctx, req)
+			}),
+		route(http.MethodPost, "/v1/accounts/{accountNumber}/close", pb.BankServer_CloseAccount_FullMethodName,
+			func() *pb.AccountStatusChange { return &pb.AccountStatusChange{} },
+			func(ctx context.Context, c Clients, req *pb.AccountStatusChange) (*pb.Response, error) {
+				bank, err := c.BankFor(ctx, req.AccountNumber)
+				if err != nil {
+					return nil, err
+				}
+				return bank.CloseAccount(ctx, req)
+			}),
+	}
+}
@@ -0,0 +1,174 @@
+package restapi
+
+import (
+	pb "assignment_2/proto"
+	"context"
+	_ "embed"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// the spec served at /openapi.yaml and checked against the routes and Stripe.proto
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
+
+// grpc clients the rest layer talks to, connections are owned by the caller
+type Clients interface {
+	PaymentGateway() pb.PaymentGatewayClient
+	//an auth server handed out by the auth load balancer
+	Authentication(ctx context.Context) (pb.AuthenticationClient, error)
+	//the bank server owning this account
+	BankFor(ctx context.Context, accountNumber string) (pb.BankServerClient, error)
+}
+
+// one http endpoint mapped onto one rpc
+type Route struct {
+	Method string
+	//net/http pattern, {name} segments fill the request field of the same json name
+	Path       string
+	GRPCMethod string
+	newRequest func() proto.Message
+	call       func(ctx context.Context, clients Clients, req proto.Message) (proto.Message, error)
+}
+
+// function to build a route with typed request and response
+func route[Req proto.Message, Resp proto.Message](method, path, grpcMethod string, newRequest func() Req, call func(context.Context, Clients, Req) (Resp, error)) Route {
+	return Route{
+		Method:     method,
+		Path:       path,
+		GRPCMethod: grpcMethod,
+		newRequest: func() proto.Message { return newRequest() },
+		call: func(ctx context.Context, clients Clients, req proto.Message) (proto.Message, error) {
+			return call(ctx, clients, req.(Req))
+		},
+	}
+}
+
+var marshaler = protojson.MarshalOptions{EmitUnpopulated: true}
+var unmarshaler = protojson.UnmarshalOptions{}
+
+// rest gateway in front of the grpc services
+type Server struct {
+	clients     Clients
+	routes      []Route
+	idempotency *idempotencyStore
+}
+
+func NewServer(clients Clients) *Server {
+	return &Server{clients: clients, routes: Routes(), idempotency: newIdempotencyStore()}
+}
+
+// function to get the http handler with every route, /openapi.yaml and /healthz
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, rt := range s.routes {
+		handler := s.serveRoute(rt)
+		if rt.Method == http.MethodPost {
+			handler = s.idempotency.wrap(handler)
+		}
+		mux.HandleFunc(rt.Method+" "+rt.Path, handler)
+	}
+
+	mux.HandleFunc("GET /openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(OpenAPISpec)
+	})
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, codes.NotFound, "No route for "+r.Method+" "+r.URL.Path)
+	})
+	return mux
+}
+
+// function to serve one route: json body and path into the request, credentials into metadata
+func (s *Server) serveRoute(rt Route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := rt.newRequest()
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			writeError(w, codes.InvalidArgument, "Failed to read request body")
+			return
+		}
+		if len(strings.TrimSpace(string(body))) > 0 {
+			err = unmarshaler.Unmarshal(body, req)
+			if err != nil {
+				writeError(w, codes.InvalidArgument, "Invalid JSON body: "+err.Error())
+				return
+			}
+		}
+
+		err = bindPath(req, rt.Path, r)
+		if err != nil {
+			writeError(w, codes.InvalidArgument, err.Error())
+			return
+		}
+
+		resp, err := rt.call(outgoingContext(r), s.clients, req)
+		if err != nil {
+			writeGRPCError(w, err)
+			return
+		}
+
+		data, err := marshaler.Marshal(resp)
+		if err != nil {
+			writeError(w, codes.Internal, "Failed to encode response")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+// function to pass the caller's bearer token or api key on to the grpc services
+func outgoingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		md.Set("authorization", auth)
+	}
+	if key := r.Header.Get("X-Api-Key"); key != "" {
+		md.Set("x-api-key", key)
+	}
+	return metadata.NewOutgoingContext(r.Context(), md)
+}
+
+// function to copy {name} path segments into string fields of the request, path wins over body
+func bindPath(req proto.Message, pattern string, r *http.Request) error {
+	message := req.ProtoReflect()
+	for _, name := range pathParams(pattern) {
+		field := message.Descriptor().Fields().ByJSONName(name)
+		if field == nil || field.Kind() != protoreflect.StringKind {
+			return errInvalidRoute(name)
+		}
+		message.Set(field, protoreflect.ValueOfString(r.PathValue(name)))
+	}
+	return nil
+}
+
+// function to get names of {name} segments of a pattern
+func pathParams(pattern string) []string {
+	var names []string
+	for _, segment := range strings.Split(pattern, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}"))
+		}
+	}
+	return names
+}
+
+type errInvalidRoute string
+
+func (e errInvalidRoute) Error() string {
+	return "Route path parameter " + string(e) + " has no string field in the request"
+}
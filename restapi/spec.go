@@ -0,0 +1,227 @@
+package restapi
+
+import (
+	authee "assignment_2/auth"
+	pb "assignment_2/proto"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// the part of an openapi document the checker looks at
+type specDocument struct {
+	Paths      map[string]map[string]specOperation `yaml:"paths"`
+	Components struct {
+		Schemas    map[string]specSchema `yaml:"schemas"`
+		Responses  map[string]specBody   `yaml:"responses"`
+		Parameters map[string]specParam  `yaml:"parameters"`
+	} `yaml:"components"`
+}
+
+type specOperation struct {
+	GRPCMethod  string              `yaml:"x-grpc-method"`
+	Parameters  []specParam         `yaml:"parameters"`
+	RequestBody *specBody           `yaml:"requestBody"`
+	Responses   map[string]specBody `yaml:"responses"`
+}
+
+type specParam struct {
+	Ref  string `yaml:"$ref"`
+	Name string `yaml:"name"`
+	In   string `yaml:"in"`
+}
+
+type specBody struct {
+	Ref     string `yaml:"$ref"`
+	Content map[string]struct {
+		Schema specSchema `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+type specSchema struct {
+	Ref          string                `yaml:"$ref"`
+	ProtoMessage string                `yaml:"x-proto-message"`
+	Properties   map[string]specSchema `yaml:"properties"`
+	Items        *specSchema           `yaml:"items"`
+}
+
+// services whose every rpc must have a rest route, except the ones only our own services may call
+var fullyMirrored = []protoreflect.FullName{"stripe.PaymentGateway"}
+
+// function to check an openapi spec against the routes and Stripe.proto
+// every route needs an operation naming its rpc, every operation needs a route,
+// and schemas must use the json names of the proto messages they stand for
+func CheckSpec(spec []byte, routes []Route) []error {
+	var doc specDocument
+	err := yaml.Unmarshal(spec, &doc)
+	if err != nil {
+		return []error{fmt.Errorf("Failed to parse openapi spec: %v", err)}
+	}
+
+	var problems []error
+	fail := func(format string, args ...any) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	//schema name : proto message it describes
+	for name, schema := range doc.Components.Schemas {
+		if schema.ProtoMessage == "" {
+			fail("schema %s has no x-proto-message", name)
+			continue
+		}
+		if schema.ProtoMessage == "none" {
+			continue
+		}
+		message := findMessage(schema.ProtoMessage)
+		if message == nil {
+			fail("schema %s: message %s is not in Stripe.proto", name, schema.ProtoMessage)
+			continue
+		}
+		for _, property := range sortedKeys(schema.Properties) {
+			if message.Fields().ByJSONName(property) == nil {
+				fail("schema %s: %s has no field %s", name, message.FullName(), property)
+			}
+		}
+	}
+
+	routed := map[string]bool{}
+	for _, rt := range routes {
+		routed[rt.Method+" "+rt.Path] = true
+		op, ok := doc.Paths[rt.Path][strings.ToLower(rt.Method)]
+		if !ok {
+			fail("%s %s is served but missing from the spec", rt.Method, rt.Path)
+			continue
+		}
+		if op.GRPCMethod != rt.GRPCMethod {
+			fail("%s %s: spec says x-grpc-method %q, route calls %q", rt.Method, rt.Path, op.GRPCMethod, rt.GRPCMethod)
+			continue
+		}
+		method := findMethod(rt.GRPCMethod)
+		if method == nil {
+			fail("%s %s: rpc %s is not in Stripe.proto", rt.Method, rt.Path, rt.GRPCMethod)
+			continue
+		}
+
+		//path parameters, declared once and matching the pattern
+		declared := map[string]bool{}
+		for _, param := range op.Parameters {
+			param = doc.resolveParam(param)
+			if param.In == "path" {
+				declared[param.Name] = true
+			}
+		}
+		for _, name := range pathParams(rt.Path) {
+			if !declared[name] {
+				fail("%s %s: path parameter %s is not declared", rt.Method, rt.Path, name)
+			}
+		}
+
+		if op.RequestBody != nil {
+			doc.checkMessage(fail, rt, "request body", *op.RequestBody, method.Input())
+		}
+		ok200, found := op.Responses["200"]
+		if !found {
+			fail("%s %s: no 200 response", rt.Method, rt.Path)
+			continue
+		}
+		doc.checkMessage(fail, rt, "200 response", ok200, method.Output())
+	}
+
+	for _, path := range sortedKeys(doc.Paths) {
+		for _, verb := range sortedKeys(doc.Paths[path]) {
+			if !routed[strings.ToUpper(verb)+" "+path] {
+				fail("%s %s is in the spec but not served", strings.ToUpper(verb), path)
+			}
+		}
+	}
+
+	//every rpc of a mirrored service needs a route
+	covered := map[string]bool{}
+	for _, rt := range routes {
+		covered[rt.GRPCMethod] = true
+	}
+	for _, name := range fullyMirrored {
+		service := pb.File_stripe_proto.Services().ByName(name.Name())
+		if service == nil {
+			fail("service %s is not in Stripe.proto", name)
+			continue
+		}
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			full := "/" + string(service.FullName()) + "/" + string(methods.Get(i).Name())
+			if !covered[full] && !authee.IsServiceOnly(full) {
+				fail("rpc %s has no rest route", full)
+			}
+		}
+	}
+	return problems
+}
+
+// function to check a json body of an operation describes the expected message
+func (doc *specDocument) checkMessage(fail func(string, ...any), rt Route, what string, body specBody, want protoreflect.MessageDescriptor) {
+	if body.Ref != "" {
+		body = doc.Components.Responses[refName(body.Ref)]
+	}
+	media, ok := body.Content["application/json"]
+	if !ok {
+		fail("%s %s: %s has no application/json content", rt.Method, rt.Path, what)
+		return
+	}
+	schema := media.Schema
+	if schema.Ref != "" {
+		schema = doc.Components.Schemas[refName(schema.Ref)]
+	}
+	if schema.ProtoMessage != string(want.FullName()) {
+		fail("%s %s: %s is %q, rpc uses %s", rt.Method, rt.Path, what, schema.ProtoMessage, want.FullName())
+	}
+}
+
+func (doc *specDocument) resolveParam(param specParam) specParam {
+	if param.Ref != "" {
+		return doc.Components.Parameters[refName(param.Ref)]
+	}
+	return param
+}
+
+// "#/components/schemas/Name" -> "Name"
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// function to find a message of Stripe.proto by full name like stripe.TransactionRequest
+func findMessage(fullName string) protoreflect.MessageDescriptor {
+	name := protoreflect.FullName(fullName)
+	if name.Parent() != pb.File_stripe_proto.Package() {
+		return nil
+	}
+	return pb.File_stripe_proto.Messages().ByName(name.Name())
+}
+
+// function to find a method of Stripe.proto by grpc full method name like /stripe.PaymentGateway/InitiateTransaction
+func findMethod(fullMethod string) protoreflect.MethodDescriptor {
+	parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), "/")
+	if len(parts) != 2 {
+		return nil
+	}
+	service := protoreflect.FullName(parts[0])
+	if service.Parent() != pb.File_stripe_proto.Package() {
+		return nil
+	}
+	found := pb.File_stripe_proto.Services().ByName(service.Name())
+	if found == nil {
+		return nil
+	}
+	return found.Methods().ByName(protoreflect.Name(parts[1]))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
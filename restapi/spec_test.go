@@ -0,0 +1,118 @@
+package restapi
+
+import (
+	pb "assignment_2/proto"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSpecMatchesRoutes(t *testing.T) {
+	for _, problem := range CheckSpec(OpenAPISpec, Routes()) {
+		t.Error(problem)
+	}
+}
+
+// function to tell whether one of the problems mentions want
+func hasProblem(problems []error, want string) bool {
+	for _, problem := range problems {
+		if strings.Contains(problem.Error(), want) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSpecCatchesMissingRoute(t *testing.T) {
+	var routes []Route
+	for _, rt := range Routes() {
+		if rt.GRPCMethod != pb.PaymentGateway_ConfirmTransaction_FullMethodName {
+			routes = append(routes, rt)
+		}
+	}
+
+	problems := CheckSpec(OpenAPISpec, routes)
+	if !hasProblem(problems, "POST /v1/payments/{transactionId}/confirm is in the spec but not served") {
+		t.Errorf("spec path without a route not reported: %v", problems)
+	}
+	if !hasProblem(problems, "rpc /stripe.PaymentGateway/ConfirmTransaction has no rest route") {
+		t.Errorf("gateway rpc without a route not reported: %v", problems)
+	}
+}
+
+func TestSpecCatchesWrongMethod(t *testing.T) {
+	spec := strings.Replace(string(OpenAPISpec), "x-grpc-method: /stripe.PaymentGateway/GetTransactionStatus", "x-grpc-method: /stripe.PaymentGateway/InitiateTransaction", 1)
+	problems := CheckSpec([]byte(spec), Routes())
+	if !hasProblem(problems, "GET /v1/payments/{transactionId}: spec says x-grpc-method") {
+		t.Errorf("wrong x-grpc-method not reported: %v", problems)
+	}
+}
+
+func TestSpecCatchesUnknownField(t *testing.T) {
+	spec := strings.Replace(string(OpenAPISpec), "        success:\n          type: boolean", "        succeeded:\n          type: boolean", 1)
+	problems := CheckSpec([]byte(spec), Routes())
+	if !hasProblem(problems, "has no field succeeded") {
+		t.Errorf("property missing from the message not reported: %v", problems)
+	}
+}
+
+// gateway answering GetTransactionStatus from a map, everything else is left unimplemented
+type fakeGateway struct {
+	pb.PaymentGatewayClient
+	statuses map[string]string
+}
+
+func (f *fakeGateway) GetTransactionStatus(ctx context.Context, req *pb.TransactionID, opts ...grpc.CallOption) (*pb.TransactionStatus, error) {
+	current, ok := f.statuses[req.TransactionId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Transaction %s not found", req.TransactionId)
+	}
+	return &pb.TransactionStatus{TransactionId: req.TransactionId, Status: current}, nil
+}
+
+type fakeClients struct {
+	gateway *fakeGateway
+}
+
+func (f fakeClients) PaymentGateway() pb.PaymentGatewayClient { return f.gateway }
+
+func (f fakeClients) Authentication(ctx context.Context) (pb.AuthenticationClient, error) {
+	return nil, status.Error(codes.Unavailable, "no auth server")
+}
+
+func (f fakeClients) BankFor(ctx context.Context, accountNumber string) (pb.BankServerClient, error) {
+	return nil, status.Error(codes.Unavailable, "no bank server")
+}
+
+func TestServerRoutesToGRPC(t *testing.T) {
+	handler := NewServer(fakeClients{gateway: &fakeGateway{statuses: map[string]string{"tx-1": "Committed"}}}).Handler()
+
+	cases := []struct {
+		method, path string
+		want         int
+		contains     string
+	}{
+		{http.MethodGet, "/v1/payments/tx-1", http.StatusOK, `"status":"Committed"`},
+		{http.MethodGet, "/v1/payments/tx-2", http.StatusNotFound, `"code":"NOT_FOUND"`},
+		{http.MethodPost, "/v1/sessions", http.StatusServiceUnavailable, `"code":"UNAVAILABLE"`},
+		{http.MethodPost, "/v1/payments/queued", http.StatusNotFound, `"code":"NOT_FOUND"`},
+	}
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(c.method, c.path, strings.NewReader("{}")))
+		if recorder.Code != c.want {
+			t.Errorf("%s %s: status %d, want %d (%s)", c.method, c.path, recorder.Code, c.want, recorder.Body)
+			continue
+		}
+		if !json.Valid(recorder.Body.Bytes()) || !strings.Contains(strings.ReplaceAll(recorder.Body.String(), " ", ""), c.contains) {
+			t.Errorf("%s %s: body %s does not contain %s", c.method, c.path, recorder.Body, c.contains)
+		}
+	}
+}
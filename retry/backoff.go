@@ -0,0 +1,34 @@
+package retry
+
+import (
+	"math/rand"
+	"time"
+)
+
+// exponential backoff between attempts of one item
+type Backoff struct {
+	//wait after the first failed attempt, doubled after every further one
+	Base time.Duration
+	Max  time.Duration
+}
+
+func DefaultBackoff() Backoff {
+	return Backoff{Base: time.Second, Max: 5 * time.Minute}
+}
+
+// function to get the wait after a number of failed attempts
+// half of it is random so items that failed together don't all come back together
+func (b Backoff) Delay(failures int) time.Duration {
+	delay := b.Base
+	for i := 1; i < failures && delay < b.Max; i++ {
+		delay *= 2
+	}
+	if b.Max > 0 && delay > b.Max {
+		delay = b.Max
+	}
+	if delay <= 0 {
+		return delay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)))
+}
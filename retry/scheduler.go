@@ -0,0 +1,255 @@
+package retry
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// function run by a worker for one attempt of an item
+// returning false means the attempt failed and the item should be tried again after a backoff
+type Handler func(key uint64, attempt int) bool
+
+// one item waiting for its next attempt
+type task struct {
+	key     uint64
+	attempt int
+	due     time.Time
+	//lower runs first once due
+	priority int
+	//which heap holds it and where, kept up to date by the heap methods
+	ready bool
+	index int
+}
+
+// heap of tasks, by due time while waiting and by priority once due
+type taskHeap struct {
+	tasks []*task
+	less  func(a, b *task) bool
+}
+
+func (h *taskHeap) Len() int           { return len(h.tasks) }
+func (h *taskHeap) Less(i, j int) bool { return h.less(h.tasks[i], h.tasks[j]) }
+func (h *taskHeap) Swap(i, j int) {
+	h.tasks[i], h.tasks[j] = h.tasks[j], h.tasks[i]
+	h.tasks[i].index = i
+	h.tasks[j].index = j
+}
+
+func (h *taskHeap) Push(x any) {
+	t := x.(*task)
+	t.index = len(h.tasks)
+	h.tasks = append(h.tasks, t)
+}
+
+func (h *taskHeap) Pop() any {
+	old := h.tasks
+	t := old[len(old)-1]
+	old[len(old)-1] = nil
+	h.tasks = old[:len(old)-1]
+	return t
+}
+
+func byDue(a, b *task) bool {
+	return a.due.Before(b.due)
+}
+
+// more urgent first, then whoever was due first, then the older key
+func byPriority(a, b *task) bool {
+	if a.priority != b.priority {
+		return a.priority < b.priority
+	}
+	if !a.due.Equal(b.due) {
+		return a.due.Before(b.due)
+	}
+	return a.key < b.key
+}
+
+// runs attempts of items when they are due on a fixed number of workers
+// nobody ever waits for a backoff: items sleep in the waiting heap, not in a goroutine,
+// and when more items are due than workers are free the most urgent ones go first
+type Scheduler struct {
+	mu      sync.Mutex
+	waiting taskHeap
+	ready   taskHeap
+	byKey   map[uint64]*task
+	running map[uint64]bool
+	backoff Backoff
+	handle  Handler
+	//items scheduled again while being attempted, they start over once the attempt ends
+	rescheduled map[uint64]*task
+
+	//poked whenever the earliest due time or the ready items may have changed
+	wake chan struct{}
+	//one token per free worker
+	idle chan struct{}
+	jobs chan *task
+}
+
+// function to create a scheduler and start its workers
+func NewScheduler(workers int, backoff Backoff, handle Handler) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+	s := &Scheduler{
+		waiting: taskHeap{less: byDue},
+		ready:   taskHeap{less: byPriority},
+		byKey:   make(map[uint64]*task),
+		running: make(map[uint64]bool),
+		backoff: backoff,
+		handle:  handle,
+		wake:    make(chan struct{}, 1),
+		idle:    make(chan struct{}, workers),
+		jobs:    make(chan *task, workers),
+
+		rescheduled: make(map[uint64]*task),
+	}
+	for i := 0; i < workers; i++ {
+		s.idle <- struct{}{}
+		go s.work()
+	}
+	go s.dispatch()
+	return s
+}
+
+// function to schedule the first attempt of an item at a time, never blocks
+// an item already waiting is moved to the new time, one being attempted right now is
+// scheduled again for that time once its attempt ends, whatever the attempt said
+func (s *Scheduler) Schedule(key uint64, at time.Time, priority int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running[key] {
+		s.rescheduled[key] = &task{key: key, attempt: 1, due: at, priority: priority}
+		return
+	}
+	t, exists := s.byKey[key]
+	if exists {
+		s.heapOf(t).remove(t)
+	} else {
+		t = &task{key: key, attempt: 1}
+		s.byKey[key] = t
+	}
+	t.due = at
+	t.priority = priority
+	t.ready = false
+	heap.Push(&s.waiting, t)
+	s.poke()
+}
+
+// number of items waiting or being attempted
+func (s *Scheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.byKey) + len(s.running)
+}
+
+// caller holds mu
+func (s *Scheduler) heapOf(t *task) *taskHeap {
+	if t.ready {
+		return &s.ready
+	}
+	return &s.waiting
+}
+
+func (h *taskHeap) remove(t *task) {
+	heap.Remove(h, t.index)
+}
+
+// caller holds mu
+func (s *Scheduler) poke() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// function to move items whose time has come to the ready heap, caller holds mu
+// returns how long until the next waiting item is due, 0 if none is waiting
+func (s *Scheduler) promoteDue(now time.Time) time.Duration {
+	for s.waiting.Len() > 0 {
+		next := s.waiting.tasks[0]
+		if next.due.After(now) {
+			return next.due.Sub(now)
+		}
+		heap.Pop(&s.waiting)
+		next.ready = true
+		heap.Push(&s.ready, next)
+	}
+	return 0
+}
+
+// function to hand due items to free workers, most urgent first
+func (s *Scheduler) dispatch() {
+	for {
+		s.mu.Lock()
+		wait := s.promoteDue(time.Now())
+		haveReady := s.ready.Len() > 0
+		s.mu.Unlock()
+
+		var timer *time.Timer
+		var due <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			due = timer.C
+		}
+
+		//with nothing ready wait for news, else for a free worker
+		//the item is picked only once a worker is free, so late urgent arrivals still jump ahead
+		idle := s.idle
+		if !haveReady {
+			idle = nil
+		}
+		gotWorker := false
+		select {
+		case <-idle:
+			gotWorker = true
+		case <-s.wake:
+		case <-due:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if !gotWorker {
+			continue
+		}
+
+		s.mu.Lock()
+		s.promoteDue(time.Now())
+		if s.ready.Len() == 0 {
+			s.mu.Unlock()
+			s.idle <- struct{}{}
+			continue
+		}
+		t := heap.Pop(&s.ready).(*task)
+		delete(s.byKey, t.key)
+		s.running[t.key] = true
+		s.mu.Unlock()
+
+		s.jobs <- t
+	}
+}
+
+func (s *Scheduler) work() {
+	for t := range s.jobs {
+		done := s.handle(t.key, t.attempt)
+
+		s.mu.Lock()
+		delete(s.running, t.key)
+		if again, ok := s.rescheduled[t.key]; ok {
+			delete(s.rescheduled, t.key)
+			heap.Push(&s.waiting, again)
+			s.byKey[t.key] = again
+		} else if !done {
+			t.due = time.Now().Add(s.backoff.Delay(t.attempt))
+			t.attempt++
+			t.ready = false
+			heap.Push(&s.waiting, t)
+			s.byKey[t.key] = t
+		}
+		s.mu.Unlock()
+
+		s.idle <- struct{}{}
+		s.poke()
+	}
+}
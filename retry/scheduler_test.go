@@ -0,0 +1,171 @@
+package retry
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var fastBackoff = Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}
+
+// function to wait until cond holds, failing the test after a while
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSchedulerBoundsWorkers(t *testing.T) {
+	const workers = 3
+	const items = 40
+
+	var running, most, finished int32
+	s := NewScheduler(workers, fastBackoff, func(key uint64, attempt int) bool {
+		now := atomic.AddInt32(&running, 1)
+		for {
+			seen := atomic.LoadInt32(&most)
+			if now <= seen || atomic.CompareAndSwapInt32(&most, seen, now) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&finished, 1)
+		return true
+	})
+
+	for key := uint64(0); key < items; key++ {
+		s.Schedule(key, time.Now(), 0)
+	}
+	eventually(t, "every item to run", func() bool { return atomic.LoadInt32(&finished) == items })
+	eventually(t, "the scheduler to drain", func() bool { return s.Len() == 0 })
+
+	if most > workers {
+		t.Fatalf("%d attempts ran at once with %d workers", most, workers)
+	}
+}
+
+func TestSchedulerRetriesWithBackoff(t *testing.T) {
+	var mu sync.Mutex
+	attempts := make(map[uint64][]int)
+	s := NewScheduler(2, fastBackoff, func(key uint64, attempt int) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[key] = append(attempts[key], attempt)
+		//key n succeeds on attempt n+1
+		return attempt > int(key)
+	})
+
+	for key := uint64(0); key < 4; key++ {
+		s.Schedule(key, time.Now(), 0)
+	}
+	eventually(t, "every item to succeed", func() bool { return s.Len() == 0 })
+
+	mu.Lock()
+	defer mu.Unlock()
+	for key := uint64(0); key < 4; key++ {
+		if len(attempts[key]) != int(key)+1 {
+			t.Errorf("key %d ran %v, want %d attempts", key, attempts[key], key+1)
+			continue
+		}
+		for i, attempt := range attempts[key] {
+			if attempt != i+1 {
+				t.Errorf("key %d attempts numbered %v", key, attempts[key])
+				break
+			}
+		}
+	}
+}
+
+func TestSchedulerRunsUrgentFirst(t *testing.T) {
+	var mu sync.Mutex
+	var order []uint64
+	block := make(chan struct{})
+	s := NewScheduler(1, fastBackoff, func(key uint64, attempt int) bool {
+		if key == 100 {
+			<-block
+		}
+		mu.Lock()
+		order = append(order, key)
+		mu.Unlock()
+		return true
+	})
+
+	//the only worker is busy while the others become due
+	s.Schedule(100, time.Now(), 0)
+	eventually(t, "the blocker to start", func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.running[100]
+	})
+	s.Schedule(1, time.Now(), 2)
+	s.Schedule(2, time.Now(), 1)
+	s.Schedule(3, time.Now(), 0)
+	time.Sleep(5 * time.Millisecond)
+	close(block)
+
+	eventually(t, "every item to run", func() bool { return s.Len() == 0 })
+	mu.Lock()
+	defer mu.Unlock()
+	want := []uint64{100, 3, 2, 1}
+	for i := range want {
+		if i >= len(order) || order[i] != want[i] {
+			t.Fatalf("ran %v, want %v", order, want)
+		}
+	}
+}
+
+func TestScheduleMovesWaitingItem(t *testing.T) {
+	ran := make(chan time.Time, 1)
+	s := NewScheduler(1, fastBackoff, func(key uint64, attempt int) bool {
+		ran <- time.Now()
+		return true
+	})
+
+	start := time.Now()
+	s.Schedule(7, start.Add(time.Hour), 0)
+	s.Schedule(7, start, 0)
+
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("item moved to now never ran")
+	}
+	if s.Len() != 0 {
+		t.Fatalf("%d items left, the moved item ran twice or stayed", s.Len())
+	}
+}
+
+// a requeue landing while the attempt is still running must not be lost
+func TestScheduleWhileRunningRunsAgain(t *testing.T) {
+	var calls int32
+	inside := make(chan struct{})
+	release := make(chan struct{})
+	s := NewScheduler(1, fastBackoff, func(key uint64, attempt int) bool {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(inside)
+			<-release
+			//the attempt gave up, as for a payment moved to the dead letters
+			return true
+		}
+		return true
+	})
+
+	s.Schedule(1, time.Now(), 0)
+	<-inside
+	s.Schedule(1, time.Now(), 0)
+	close(release)
+
+	eventually(t, "the requeued item to run again", func() bool { return atomic.LoadInt32(&calls) == 2 })
+	eventually(t, "the scheduler to drain", func() bool { return s.Len() == 0 })
+	time.Sleep(10 * time.Millisecond)
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Fatalf("item ran %d times, want 2", n)
+	}
+}
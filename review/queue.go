@@ -0,0 +1,227 @@
+package review
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// what can happen to a payment in review, every one of them lands in the journal
+const (
+	ActionHeld     = "held"
+	ActionApproved = "approved"
+	ActionRejected = "rejected"
+)
+
+// returned when a payment is not (or no longer) waiting for review
+var ErrNotPending = errors.New("payment is not pending review")
+
+// a payment waiting for an operator
+type Item struct {
+	TransactionId string    `json:"transactionId"`
+	SenderId      string    `json:"senderId"`
+	ReceiverId    string    `json:"receiverId"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	MerchantId    string    `json:"merchantId,omitempty"`
+	Score         int       `json:"score"`
+	Rules         []string  `json:"rules"`
+	HeldAt        time.Time `json:"heldAt"`
+	//user who started the payment, the one allowed to follow it
+	Initiator string `json:"initiator,omitempty"`
+}
+
+// one line of the journal, the queue is rebuilt from these on startup
+// and the same lines are the audit trail of who did what
+type Event struct {
+	Action        string    `json:"action"`
+	TransactionId string    `json:"transactionId"`
+	Actor         string    `json:"actor"`
+	Note          string    `json:"note,omitempty"`
+	At            time.Time `json:"at"`
+	//only set on held
+	Item *Item `json:"item,omitempty"`
+}
+
+// manual review queue kept in an append-only journal file
+type Queue struct {
+	mu      sync.Mutex
+	file    *os.File
+	pending map[string]*Item
+	//set when a torn line couldn't be cut off, nothing more may be written after it
+	broken error
+}
+
+// function to open the journal at path, creating it if needed, and replay it
+func Open(path string) (*Queue, error) {
+	q := &Queue{pending: make(map[string]*Item)}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open review journal: %v", err)
+	}
+
+	good, err := q.replay(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	//cut a torn last line from a crash mid write, else the next event would be glued onto it
+	err = file.Truncate(good)
+	if err == nil {
+		_, err = file.Seek(good, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("Failed to repair review journal: %v", err)
+	}
+
+	q.file = file
+	return q, nil
+}
+
+// function to rebuild pending items from the journal, returns the size of its intact part
+func (q *Queue) replay(file *os.File) (int64, error) {
+	reader := bufio.NewReader(file)
+	var good int64
+	line := 0
+	for {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			//anything without a newline at the end never finished writing
+			return good, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("Failed to read review journal: %v", err)
+		}
+		line++
+
+		var event Event
+		errJson := json.Unmarshal(data, &event)
+		if errJson != nil {
+			return 0, fmt.Errorf("Corrupt review journal at line %d: %v", line, errJson)
+		}
+		q.apply(event)
+		good += int64(len(data))
+	}
+}
+
+// function to apply one event to the pending items, caller holds mu
+func (q *Queue) apply(event Event) {
+	switch event.Action {
+	case ActionHeld:
+		if event.Item != nil {
+			q.pending[event.TransactionId] = event.Item
+		}
+	case ActionApproved, ActionRejected:
+		delete(q.pending, event.TransactionId)
+	}
+}
+
+// function to write one event and sync it before anyone is told it happened, caller holds mu
+// a failed write is cut off again, so the next event isn't glued onto half a line
+func (q *Queue) append(event Event) error {
+	if q.broken != nil {
+		return q.broken
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	offset, err := q.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("Failed to write review journal: %v", err)
+	}
+	_, err = q.file.Write(append(data, '\n'))
+	if err == nil {
+		err = q.file.Sync()
+	}
+	if err != nil {
+		errTruncate := q.file.Truncate(offset)
+		if errTruncate == nil {
+			_, errTruncate = q.file.Seek(offset, io.SeekStart)
+		}
+		if errTruncate != nil {
+			//the torn line stays last, so the next open cuts it off
+			q.broken = fmt.Errorf("Review journal needs a restart to repair: %v", errTruncate)
+		}
+		return fmt.Errorf("Failed to write review journal: %v", err)
+	}
+
+	q.apply(event)
+	return nil
+}
+
+// function to put a payment on hold, actor is who held it (the fraud rules)
+func (q *Queue) Hold(item Item, actor string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if item.HeldAt.IsZero() {
+		item.HeldAt = time.Now()
+	}
+	return q.append(Event{
+		Action:        ActionHeld,
+		TransactionId: item.TransactionId,
+		Actor:         actor,
+		At:            item.HeldAt,
+		Item:          &item,
+	})
+}
+
+// function to take a payment out of review with the operator's decision
+// only one caller ever gets the item, a second decision gets ErrNotPending
+func (q *Queue) Resolve(transactionId, action, actor, note string) (Item, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if action != ActionApproved && action != ActionRejected {
+		return Item{}, fmt.Errorf("Unknown review action %q", action)
+	}
+
+	item, exists := q.pending[transactionId]
+	if !exists {
+		return Item{}, ErrNotPending
+	}
+
+	err := q.append(Event{
+		Action:        action,
+		TransactionId: transactionId,
+		Actor:         actor,
+		Note:          note,
+		At:            time.Now(),
+	})
+	if err != nil {
+		return Item{}, err
+	}
+	return *item, nil
+}
+
+// function to list pending payments, oldest first
+func (q *Queue) Pending() []Item {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	items := make([]Item, 0, len(q.pending))
+	for _, item := range q.pending {
+		items = append(items, *item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].HeldAt.Before(items[j].HeldAt)
+	})
+	return items
+}
+
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.file.Close()
+}
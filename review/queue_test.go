@@ -0,0 +1,136 @@
+package review
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func openQueue(t *testing.T, path string) *Queue {
+	t.Helper()
+	q, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { q.Close() })
+	return q
+}
+
+func hold(t *testing.T, q *Queue, id string, heldAt time.Time) {
+	t.Helper()
+	err := q.Hold(Item{TransactionId: id, SenderId: "alice", ReceiverId: "bob", Amount: 5000, Score: 50, Rules: []string{"big"}, HeldAt: heldAt}, "fraud-rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func pendingIds(q *Queue) []string {
+	ids := []string{}
+	for _, item := range q.Pending() {
+		ids = append(ids, item.TransactionId)
+	}
+	return ids
+}
+
+func TestPendingSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review.jsonl")
+	now := time.Now()
+
+	q := openQueue(t, path)
+	hold(t, q, "tx-2", now)
+	hold(t, q, "tx-1", now.Add(-time.Minute))
+	hold(t, q, "tx-3", now.Add(time.Minute))
+	if _, err := q.Resolve("tx-2", ActionApproved, "op", "checked with sender"); err != nil {
+		t.Fatal(err)
+	}
+	q.Close()
+
+	q = openQueue(t, path)
+	if got := strings.Join(pendingIds(q), ","); got != "tx-1,tx-3" {
+		t.Fatalf("pending after restart %s, want tx-1,tx-3 oldest first", got)
+	}
+	item := q.Pending()[0]
+	if item.SenderId != "alice" || item.Amount != 5000 || item.Rules[0] != "big" {
+		t.Fatalf("item not rebuilt: %+v", item)
+	}
+}
+
+func TestTornLastLineIsCutOnOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review.jsonl")
+
+	q := openQueue(t, path)
+	hold(t, q, "tx-1", time.Now())
+	q.Close()
+	intact, _ := os.Stat(path)
+
+	//crash half way through writing the next event
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	file.WriteString(`{"action":"held","transactionId":"tx-2","item":{"transac`)
+	file.Close()
+
+	q = openQueue(t, path)
+	if got := strings.Join(pendingIds(q), ","); got != "tx-1" {
+		t.Fatalf("pending %s, want only tx-1", got)
+	}
+	repaired, _ := os.Stat(path)
+	if repaired.Size() != intact.Size() {
+		t.Fatalf("journal is %d bytes after repair, want %d", repaired.Size(), intact.Size())
+	}
+
+	//the next event starts on its own line
+	hold(t, q, "tx-3", time.Now())
+	q.Close()
+	q = openQueue(t, path)
+	if got := strings.Join(pendingIds(q), ","); got != "tx-1,tx-3" {
+		t.Fatalf("pending %s after writing past the repair, want tx-1,tx-3", got)
+	}
+}
+
+func TestCorruptLineInTheMiddleRefusesOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review.jsonl")
+	q := openQueue(t, path)
+	hold(t, q, "tx-1", time.Now())
+	q.Close()
+
+	data, _ := os.ReadFile(path)
+	os.WriteFile(path, append([]byte("not json\n"), data...), 0600)
+
+	if _, err := Open(path); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Fatalf("got %v, want an error naming line 1", err)
+	}
+}
+
+func TestResolveOnlyOnce(t *testing.T) {
+	q := openQueue(t, filepath.Join(t.TempDir(), "review.jsonl"))
+	hold(t, q, "tx-1", time.Now())
+
+	if _, err := q.Resolve("tx-1", "maybe", "op", ""); err == nil {
+		t.Fatal("unknown action accepted")
+	}
+	item, err := q.Resolve("tx-1", ActionRejected, "op", "looks like fraud")
+	if err != nil || item.TransactionId != "tx-1" {
+		t.Fatalf("got %+v, %v", item, err)
+	}
+	if _, err := q.Resolve("tx-1", ActionApproved, "op2", ""); !errors.Is(err, ErrNotPending) {
+		t.Fatalf("second decision: got %v, want %v", err, ErrNotPending)
+	}
+	if _, err := q.Resolve("tx-unknown", ActionApproved, "op", ""); !errors.Is(err, ErrNotPending) {
+		t.Fatalf("unknown payment: got %v, want %v", err, ErrNotPending)
+	}
+}
+
+func TestFailedWriteChangesNothing(t *testing.T) {
+	q := openQueue(t, filepath.Join(t.TempDir(), "review.jsonl"))
+	hold(t, q, "tx-1", time.Now())
+
+	q.file.Close()
+	if _, err := q.Resolve("tx-1", ActionApproved, "op", ""); err == nil {
+		t.Fatal("decision accepted without reaching the journal")
+	}
+	if got := strings.Join(pendingIds(q), ","); got != "tx-1" {
+		t.Fatalf("pending %s, want tx-1 still waiting", got)
+	}
+}
@@ -0,0 +1,126 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// how often we look at the files again, handshakes in between reuse what we have
+var reloadCheckInterval = 5 * time.Second
+
+// function to get the newest modification time of the given files
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// keeps a key pair in memory and reloads it once the files get rotated
+type certReloader struct {
+	certFile  string
+	keyFile   string
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	lastCheck time.Time
+}
+
+func newCertReloader(certFile, keyFile string) *certReloader {
+	return &certReloader{certFile: certFile, keyFile: keyFile}
+}
+
+// function to get current certificate, rotated files are loaded on the fly
+// a broken rotation keeps serving the old certificate
+func (r *certReloader) get() (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cert != nil && time.Since(r.lastCheck) < reloadCheckInterval {
+		return r.cert, nil
+	}
+	r.lastCheck = time.Now()
+
+	modTime, err := latestModTime(r.certFile, r.keyFile)
+	if err == nil && r.cert != nil && !modTime.After(r.modTime) {
+		return r.cert, nil
+	}
+
+	cert, errLoad := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err == nil {
+		err = errLoad
+	}
+	if err != nil {
+		if r.cert != nil {
+			log.Printf("Failed to reload certificate %s, keeping old one: %v", r.certFile, err)
+			return r.cert, nil
+		}
+		return nil, fmt.Errorf("Failed to load certificate %s: %v", r.certFile, err)
+	}
+
+	if r.cert != nil {
+		log.Printf("Reloaded rotated certificate %s", r.certFile)
+	}
+	r.cert = &cert
+	r.modTime = modTime
+	return r.cert, nil
+}
+
+// same as certReloader but for a CA bundle
+type poolReloader struct {
+	caFile    string
+	mu        sync.Mutex
+	pool      *x509.CertPool
+	modTime   time.Time
+	lastCheck time.Time
+}
+
+func newPoolReloader(caFile string) *poolReloader {
+	return &poolReloader{caFile: caFile}
+}
+
+func (r *poolReloader) get() (*x509.CertPool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pool != nil && time.Since(r.lastCheck) < reloadCheckInterval {
+		return r.pool, nil
+	}
+	r.lastCheck = time.Now()
+
+	modTime, err := latestModTime(r.caFile)
+	if err == nil && r.pool != nil && !modTime.After(r.modTime) {
+		return r.pool, nil
+	}
+
+	pool, errLoad := loadCertPool(r.caFile)
+	if err == nil {
+		err = errLoad
+	}
+	if err != nil {
+		if r.pool != nil {
+			log.Printf("Failed to reload CA %s, keeping old one: %v", r.caFile, err)
+			return r.pool, nil
+		}
+		return nil, err
+	}
+
+	if r.pool != nil {
+		log.Printf("Reloaded rotated CA %s", r.caFile)
+	}
+	r.pool = pool
+	r.modTime = modTime
+	return r.pool, nil
+}
@@ -0,0 +1,119 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// function to run a handshake between a server and a client config over a pipe
+func handshake(t *testing.T, server, client *tls.Config) error {
+	t.Helper()
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- tls.Server(serverConn, server).Handshake()
+	}()
+	err := tls.Client(clientConn, client).Handshake()
+	if err != nil {
+		//unblock the server side waiting for our flight
+		clientConn.Close()
+	}
+	<-done
+	return err
+}
+
+func serviceConfig(dir, service string, mutual bool) Config {
+	return Config{
+		CertFile: filepath.Join(dir, service+".pem"),
+		KeyFile:  filepath.Join(dir, service+"-key.pem"),
+		CAFile:   filepath.Join(dir, "ca.pem"),
+		Mutual:   mutual,
+	}
+}
+
+func TestClientVerifiesServer(t *testing.T) {
+	dir := t.TempDir()
+	_, err := GenerateLocalCA(dir, "gateway", "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mutual := range []bool{false, true} {
+		server, err := serviceConfig(dir, "gateway", mutual).ServerTLSConfig()
+		if err != nil {
+			t.Fatal(err)
+		}
+		client, err := serviceConfig(dir, "client", mutual).ClientTLSConfig()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		client.ServerName = "localhost"
+		if err := handshake(t, server, client); err != nil {
+			t.Fatalf("mutual=%v: handshake failed: %v", mutual, err)
+		}
+		client.ServerName = "bank.example.com"
+		if err := handshake(t, server, client); err == nil {
+			t.Fatalf("mutual=%v: handshake passed for a name the cert doesn't cover", mutual)
+		}
+	}
+}
+
+func TestClientRejectsOtherCA(t *testing.T) {
+	dir := t.TempDir()
+	other := t.TempDir()
+	if _, err := GenerateLocalCA(dir, "client"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GenerateLocalCA(other, "gateway"); err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := serviceConfig(other, "gateway", false).ServerTLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := serviceConfig(dir, "client", false).ClientTLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.ServerName = "localhost"
+	if err := handshake(t, server, client); err == nil {
+		t.Fatal("handshake passed with a server cert from another CA")
+	}
+}
+
+func TestClientPicksUpRotatedCA(t *testing.T) {
+	old := reloadCheckInterval
+	reloadCheckInterval = 0
+	defer func() { reloadCheckInterval = old }()
+
+	dir := t.TempDir()
+	if _, err := GenerateLocalCA(dir, "gateway", "client"); err != nil {
+		t.Fatal(err)
+	}
+	client, err := serviceConfig(dir, "client", false).ClientTLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.ServerName = "localhost"
+
+	//a new CA replaces ca.pem and the server cert, the client config is kept as is
+	time.Sleep(10 * time.Millisecond)
+	if _, err := GenerateLocalCA(dir, "gateway"); err != nil {
+		t.Fatal(err)
+	}
+	server, err := serviceConfig(dir, "gateway", false).ServerTLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := handshake(t, server, client); err != nil {
+		t.Fatalf("client kept the old CA after rotation: %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RFC 6238 parameters, the defaults every authenticator app understands
+const (
+	digits     = 6
+	period     = 30
+	secretSize = 20
+	//codes one step before or after are still accepted for clock drift
+	skewSteps = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// function to generate a new random base32 secret
+func GenerateSecret() (string, error) {
+	raw := make([]byte, secretSize)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", fmt.Errorf("Failed to generate totp secret: %v", err)
+	}
+	return encoding.EncodeToString(raw), nil
+}
+
+// function to build the otpauth uri which authenticator apps scan as a qr code
+func ProvisioningURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(digits))
+	query.Set("period", fmt.Sprint(period))
+
+	label := url.PathEscape(issuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// function to compute the code for one time step
+func codeForStep(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("Invalid totp secret: %v", err)
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	//dynamic truncation from RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod), nil
+}
+
+// function to compute the code valid at time t
+func CodeAt(secret string, t time.Time) (string, error) {
+	return codeForStep(secret, t.Unix()/period)
+}
+
+// function to check a code at time t
+// returns the time step it matched so callers can refuse reusing a code, codes at or before lastStep are rejected
+func Validate(secret, code string, t time.Time, lastStep int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != digits {
+		return 0, false
+	}
+
+	current := t.Unix() / period
+	for step := current - skewSteps; step <= current+skewSteps; step++ {
+		if step <= lastStep {
+			continue
+		}
+		expected, err := codeForStep(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// function to generate single use recovery codes like "3f9a-1c2e"
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	codes := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		raw := make([]byte, 4)
+		_, err := rand.Read(raw)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to generate recovery code: %v", err)
+		}
+		encoded := hex.EncodeToString(raw)
+		codes = append(codes, encoded[:4]+"-"+encoded[4:])
+	}
+	return codes, nil
+}
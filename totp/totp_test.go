@@ -0,0 +1,135 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// "12345678901234567890", the sha1 key of RFC 6238 appendix B
+var rfcSecret = encoding.EncodeToString([]byte("12345678901234567890"))
+
+// RFC 6238 appendix B sha1 vectors, we hand out 6 digits so only the last 6 of each
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestCodeAtMatchesRFC6238(t *testing.T) {
+	for _, v := range rfcVectors {
+		code, err := CodeAt(rfcSecret, time.Unix(v.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != v.code {
+			t.Errorf("code at %d = %s, want %s", v.unix, code, v.code)
+		}
+	}
+}
+
+func TestValidateRFC6238(t *testing.T) {
+	for _, v := range rfcVectors {
+		step, ok := Validate(rfcSecret, v.code, time.Unix(v.unix, 0), 0)
+		if !ok || step != v.unix/period {
+			t.Errorf("code at %d: got step %d ok %v, want step %d", v.unix, step, ok, v.unix/period)
+		}
+	}
+}
+
+func TestValidateAcceptsLowercaseSecretAndSpaces(t *testing.T) {
+	if _, ok := Validate(strings.ToLower(rfcSecret), " 287082 ", time.Unix(59, 0), 0); !ok {
+		t.Fatal("code refused")
+	}
+}
+
+func TestValidateAllowsOneStepOfDrift(t *testing.T) {
+	at := time.Unix(1111111109, 0)
+	code, _ := CodeAt(rfcSecret, at)
+
+	cases := []struct {
+		name string
+		at   time.Time
+		ok   bool
+	}{
+		{"same step", at, true},
+		{"one step later", at.Add(period * time.Second), true},
+		{"one step earlier", at.Add(-period * time.Second), true},
+		{"two steps later", at.Add(2 * period * time.Second), false},
+		{"two steps earlier", at.Add(-2 * period * time.Second), false},
+	}
+	for _, c := range cases {
+		if _, ok := Validate(rfcSecret, code, c.at, 0); ok != c.ok {
+			t.Errorf("%s: ok %v, want %v", c.name, ok, c.ok)
+		}
+	}
+}
+
+func TestValidateRefusesReplayOfLastStep(t *testing.T) {
+	at := time.Unix(1234567890, 0)
+	code, _ := CodeAt(rfcSecret, at)
+
+	step, ok := Validate(rfcSecret, code, at, 0)
+	if !ok {
+		t.Fatal("first use refused")
+	}
+	if _, ok := Validate(rfcSecret, code, at, step); ok {
+		t.Fatal("same code accepted twice")
+	}
+	//still inside the drift window, but already used
+	if _, ok := Validate(rfcSecret, code, at.Add(period*time.Second), step); ok {
+		t.Fatal("code replayed in the next step")
+	}
+
+	next, _ := CodeAt(rfcSecret, at.Add(period*time.Second))
+	if nextStep, ok := Validate(rfcSecret, next, at.Add(period*time.Second), step); !ok || nextStep != step+1 {
+		t.Fatalf("next code: got step %d ok %v, want step %d", nextStep, ok, step+1)
+	}
+}
+
+func TestValidateRefusesMalformedCodes(t *testing.T) {
+	at := time.Unix(59, 0)
+	for _, code := range []string{"", "28708", "2870820", "94287082", "abcdef", "287083"} {
+		if _, ok := Validate(rfcSecret, code, at, 0); ok {
+			t.Errorf("code %q accepted", code)
+		}
+	}
+	if _, ok := Validate("not base32!", "287082", at, 0); ok {
+		t.Error("code accepted for a broken secret")
+	}
+}
+
+func TestGeneratedSecretWorks(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	code, err := CodeAt(secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Validate(secret, code, now, 0); !ok {
+		t.Fatal("code for a fresh secret refused")
+	}
+}
+
+func TestRecoveryCodesAreDistinct(t *testing.T) {
+	recovery, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]bool{}
+	for _, code := range recovery {
+		if len(code) != 9 || code[4] != '-' || seen[code] {
+			t.Fatalf("bad or repeated recovery code %q", code)
+		}
+		seen[code] = true
+	}
+}
@@ -1,60 +1,287 @@
 package main
 
 import (
+	authee "assignment_2/auth"
+	"assignment_2/eventbus"
 	pb "assignment_2/proto"
+	"assignment_2/tlsutil"
+	"assignment_2/txlog"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
-	"sync"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 type TransactionLogger struct {
 	pb.UnimplementedLoggingServiceServer
-	mu      sync.Mutex
-	logFile *os.File
+	//json lines files with a sequence number per record, rotated by size and age
+	log *txlog.Log
 }
 
-// function to initialize the log file
+// records returned by QueryLogs when the caller gives no limit, and the most it may ask for
+const (
+	defaultQueryLimit = 100
+	maxQueryLimit     = 1000
+)
+
+// how often what was logged since the last checkpoint gets signed, on top of every txlog.CheckpointEvery records
+const checkpointInterval = time.Minute
+
+// function to open the transaction log, configured from env
+//
+//	TRANSACTION_LOG_DIR      where the log files go (default transaction_log)
+//	TRANSACTION_LOG_MAX_SIZE bytes per file before a new one is started (default 16MiB)
+//	TRANSACTION_LOG_MAX_AGE  how long a file takes records before a new one is started (default 24h)
+//	TRANSACTION_LOG_KEY_FILE key the checkpoints are signed with, made on first start with its
+//	                         public half next to it in .pub (default transaction_log.key)
 func NewTransactionLogger() *TransactionLogger {
-	file, err := os.OpenFile("transactions.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	dir := os.Getenv("TRANSACTION_LOG_DIR")
+	if dir == "" {
+		dir = "transaction_log"
+	}
+
+	var maxSize int64
+	if value := os.Getenv("TRANSACTION_LOG_MAX_SIZE"); value != "" {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 1 {
+			log.Fatalf("Invalid TRANSACTION_LOG_MAX_SIZE %q", value)
+		}
+		maxSize = n
+	}
+	var maxAge time.Duration
+	if value := os.Getenv("TRANSACTION_LOG_MAX_AGE"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			log.Fatalf("Invalid TRANSACTION_LOG_MAX_AGE %q", value)
+		}
+		maxAge = d
+	}
+
+	keyFile := os.Getenv("TRANSACTION_LOG_KEY_FILE")
+	if keyFile == "" {
+		keyFile = "transaction_log.key"
+	}
+	key, err := txlog.LoadSigningKey(keyFile)
 	if err != nil {
-		log.Fatalf("Cannot open log file: %v", err)
+		log.Fatalf("%v", err)
 	}
 
-	return &TransactionLogger{logFile: file}
+	txLog, err := txlog.Open(dir, maxSize, maxAge, key)
+	if err != nil {
+		log.Fatalf("Cannot open transaction log: %v", err)
+	}
+	return &TransactionLogger{log: txLog}
 }
 
 func (tl *TransactionLogger) LogTransaction(ctx context.Context, req *pb.LogEntry) (*pb.Response, error) {
-	tl.mu.Lock()
-	defer tl.mu.Unlock()
+	_, err := tl.appendEntry(req)
+	if err != nil {
+		return &pb.Response{Status: "Log Failed"}, status.Errorf(codes.Unavailable, "%v", err)
+	}
+	return &pb.Response{Status: "Logged Successfully"}, nil
+}
 
-	logEntry := fmt.Sprintf("[%s] Transaction ID: %s | Client: %s | Amount: %.2f | Status: %s\n",
-		req.Timestamp, req.TransactionId, req.ClientId, req.Amount, req.Status)
+// function to take a batch of entries from a log shipper, answered once every one is written
+// a batch is sent again whole when the answer got lost, entries already in the log are skipped by their id
+func (tl *TransactionLogger) StreamLogs(stream pb.LoggingService_StreamLogsServer) error {
+	ack := &pb.LogAck{}
+	for {
+		entry, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(ack)
+		}
+		if err != nil {
+			return err
+		}
 
-	_, err := tl.logFile.WriteString(logEntry)
+		duplicate, err := tl.appendEntry(entry)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "Logged %d entries of the batch: %v", ack.Received, err)
+		}
+		ack.Received++
+		if duplicate {
+			ack.Duplicates++
+		}
+	}
+}
 
+// function to write one entry, duplicate is true when its entry id was already logged
+func (tl *TransactionLogger) appendEntry(entry *pb.LogEntry) (bool, error) {
+	record, err := tl.log.Append(txlog.Record{
+		TransactionId: entry.TransactionId,
+		ClientId:      entry.ClientId,
+		Amount:        entry.Amount,
+		Status:        entry.Status,
+		Timestamp:     entry.Timestamp,
+		Reason:        entry.Reason,
+		EntryId:       entry.EntryId,
+	})
+	if errors.Is(err, txlog.ErrDuplicate) {
+		fmt.Printf("Skipped entry %s, already logged as #%d\n", entry.EntryId, record.Seq)
+		return true, nil
+	}
 	if err != nil {
-		return &pb.Response{Status: "Log Failed"}, err
+		return false, err
 	}
 
-	fmt.Println("Logged Transaction:", logEntry)
-	return &pb.Response{Status: "Logged Successfully"}, nil
+	fmt.Printf("Logged Transaction #%d: %s | Client: %s | Amount: %.2f | Status: %s\n", record.Seq, record.TransactionId, record.ClientId, record.Amount, record.Status)
+	return false, nil
+}
 
+// function to log every payment event off the bus, as consumer group transaction-logger
+// the event id is the entry id, so an event handed over again after a restart is skipped
+// the bus is the only source of payment outcomes, 2PC ships just the credits it made
+func (tl *TransactionLogger) consumePaymentEvents(bus eventbus.Bus) {
+	err := bus.Subscribe(context.Background(), eventbus.TopicPayments, "transaction-logger", func(ctx context.Context, event eventbus.Event) error {
+		payment, err := eventbus.DecodePayment(event)
+		if err != nil {
+			fmt.Printf("Skipped event %s, not a payment event: %v\n", event.Id(), err)
+			return nil
+		}
+
+		//the account the event is about, bank credits only have a receiver
+		clientId := payment.SenderId
+		if clientId == "" {
+			clientId = payment.ReceiverId
+		}
+		_, err = tl.appendEntry(&pb.LogEntry{
+			TransactionId: payment.TransactionId,
+			ClientId:      clientId,
+			Amount:        payment.Amount,
+			Status:        payment.Type,
+			Timestamp:     event.At.Format(time.RFC3339),
+			EntryId:       event.Id(),
+		})
+		return err
+	})
+	if err != nil {
+		log.Fatalf("Failed to consume payment events: %v", err)
+	}
+}
+
+// function to search the log by transaction, client, status and time range, oldest first
+func (tl *TransactionLogger) QueryLogs(ctx context.Context, req *pb.LogQuery) (*pb.LogRecordList, error) {
+	filter := txlog.Filter{
+		TransactionId: req.TransactionId,
+		ClientId:      req.ClientId,
+		Status:        req.Status,
+		AfterSeq:      req.AfterSequence,
+		Limit:         int(req.Limit),
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultQueryLimit
+	}
+	if filter.Limit > maxQueryLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "Limit %d is above the maximum of %d", req.Limit, maxQueryLimit)
+	}
+
+	var err error
+	if req.From != "" {
+		filter.From, err = time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid from time: %v", err)
+		}
+	}
+	if req.To != "" {
+		filter.To, err = time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid to time: %v", err)
+		}
+	}
+
+	records, more, err := tl.log.Query(filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	list := &pb.LogRecordList{More: more}
+	for _, record := range records {
+		list.Records = append(list.Records, &pb.LogRecord{
+			Sequence:      record.Seq,
+			LoggedAt:      record.LoggedAt.Format(time.RFC3339Nano),
+			TransactionId: record.TransactionId,
+			ClientId:      record.ClientId,
+			Amount:        record.Amount,
+			Status:        record.Status,
+			Timestamp:     record.Timestamp,
+			Reason:        record.Reason,
+			PrevHash:      record.PrevHash,
+			Hash:          record.Hash,
+			EntryId:       record.EntryId,
+		})
+	}
+	return list, nil
+}
+
+// function to check that no record was changed, deleted or moved since it was logged
+func (tl *TransactionLogger) VerifyLog(ctx context.Context, req *pb.Empty) (*pb.LogVerification, error) {
+	report, err := tl.log.Verify()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	verification := &pb.LogVerification{
+		Ok:           report.OK(),
+		Records:      report.Records,
+		LastSequence: report.LastSeq,
+		HeadHash:     report.Head,
+		Checkpoints:  int32(report.Checkpoints),
+	}
+	for _, problem := range report.Problems {
+		verification.Problems = append(verification.Problems, &pb.LogProblem{
+			Sequence: problem.Seq,
+			File:     problem.File,
+			Line:     int32(problem.Line),
+			Problem:  problem.Reason,
+		})
+	}
+	return verification, nil
+}
+
+// function to sign what was logged into a checkpoint now and then, so a quiet log is covered too
+func (tl *TransactionLogger) checkpointPeriodically() {
+	ticker := time.NewTicker(checkpointInterval)
+	for range ticker.C {
+		err := tl.log.Checkpoint()
+		if err != nil {
+			fmt.Println("Failed to checkpoint transaction log:", err)
+		}
+	}
 }
 
 func main() {
+	//service tokens can't be signed or checked without the shared secret
+	if err := authee.LoadServiceSecret(); err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	listen, err := net.Listen("tcp", ":50058")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.Creds(tlsutil.ServerCredentials(authee.ServiceTransactionLog)), grpc.UnaryInterceptor(authee.AuthInterceptor), grpc.StreamInterceptor(authee.StreamAuthInterceptor))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
 	logger := NewTransactionLogger()
 	pb.RegisterLoggingServiceServer(grpcServer, logger)
+	go logger.checkpointPeriodically()
+
+	bus, err := eventbus.OpenDefault()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	go logger.consumePaymentEvents(bus)
 
 	fmt.Println("Transaction Logger running on port 50058")
 	err = grpcServer.Serve(listen)
@@ -1,15 +1,29 @@
 package main
 
 import (
+	authee "assignment_2/auth"
+	"assignment_2/eventbus"
+	"assignment_2/fees"
+	"assignment_2/logship"
 	pb "assignment_2/proto"
+	"assignment_2/tlsutil"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 type TwoPhaseCommitServer struct {
@@ -19,16 +33,74 @@ type TwoPhaseCommitServer struct {
 
 var bankLoadBalancerAddressHere = "localhost:50056"
 
-func logTransaction(transactionId, clientId string, amount float64, status string) {
-	conn, err := grpc.Dial("localhost:50058", grpc.WithInsecure())
+// fees taken from merchant payments, none unless FEE_SCHEDULE_FILE is set
+var feeSchedule fees.Config
+
+// committed, aborted and refunded payments go out here, webhooks reach merchants from there
+var eventBus eventbus.Bus
+
+// details seen in prepare, so an abort knows which merchant to notify, guarded by tpc.mu
+var preparedPayments = make(map[string]*pb.TransactionDetails)
+
+// payments refunded after a failed credit, they never run again under the same id, guarded by tpc.mu
+var refundedPayments = make(map[string]bool)
+
+// refunds started but not finished yet, retried in the background until they are, guarded by tpc.mu
+var pendingRefunds = make(map[string]*refund)
+
+// how often unfinished refunds are tried again
+const refundRetryInterval = 30 * time.Second
+
+// where refunds are kept across restarts as json lines, REFUNDED_PAYMENTS_FILE moves it
+// a refund is written once when it starts and once more when it is done
+var refundedPaymentsPath = "two_phase_commit_refunds.log"
+
+// money given back to the sender of a payment whose credits failed part way
+// every credit is reversed first, so nobody keeps a part of money the sender gets back
+type refund struct {
+	TransactionId string           `json:"transactionId"`
+	SenderId      string           `json:"senderId,omitempty"`
+	ReceiverId    string           `json:"receiverId,omitempty"`
+	MerchantId    string           `json:"merchantId,omitempty"`
+	Amount        float64          `json:"amount,omitempty"`
+	Credits       []refundedCredit `json:"credits,omitempty"`
+	Done          bool             `json:"done,omitempty"`
+}
+
+// one credit of a refunded payment, reversed before the sender gets the money back
+type refundedCredit struct {
+	Account string  `json:"account"`
+	Amount  float64 `json:"amount"`
+}
+
+// every call to other services goes over tls and carries our service token
+var transportSecurity = grpc.WithTransportCredentials(tlsutil.ClientCredentials(authee.ServiceTwoPhaseCommit))
+var serviceIdentity = grpc.WithPerRPCCredentials(authee.NewServiceCredentials(authee.ServiceTwoPhaseCommit))
+
+var transactionLoggerAddress = "localhost:50058"
+
+// every log entry goes through a local spool, so none is lost while the logger is down
+var logShipper *logship.Shipper
+
+// function to open the log spool, LOG_SPOOL_DIR moves it
+func openLogShipper() {
+	dir := os.Getenv("LOG_SPOOL_DIR")
+	if dir == "" {
+		dir = "two_phase_commit_log_spool"
+	}
+
+	shipper, err := logship.Open(dir, transactionLoggerAddress, transportSecurity, serviceIdentity)
 	if err != nil {
-		fmt.Println("Failed to connect to logging service")
-		return
+		log.Fatalf("%v", err)
 	}
-	defer conn.Close()
+	logShipper = shipper
+	fmt.Printf("Log spool opened, %d entries still to ship\n", shipper.Pending())
+}
 
-	client := pb.NewLoggingServiceClient(conn)
-	_, err = client.LogTransaction(context.Background(), &pb.LogEntry{
+// function to log what only 2PC knows, how a payment was split and refunds still pending
+// outcomes (committed, aborted, refunded) reach the log as payment events instead
+func logTransaction(transactionId, clientId string, amount float64, status string) {
+	err := logShipper.Log(&pb.LogEntry{
 		TransactionId: transactionId,
 		ClientId:      clientId,
 		Amount:        amount,
@@ -40,9 +112,186 @@ func logTransaction(transactionId, clientId string, amount float64, status strin
 	}
 }
 
+// function to load the fee schedule from the json file named in env
+func loadFeeSchedule() {
+	path := os.Getenv("FEE_SCHEDULE_FILE")
+	if path == "" {
+		return
+	}
+
+	config, err := fees.LoadConfig(path)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	feeSchedule = config
+	fmt.Printf("Loaded fee schedule, platform account %s, %d merchant schedules\n", config.PlatformAccount, len(config.Merchants))
+}
+
+// function to load the refunds of before a restart, unfinished ones are retried
+func loadRefundedPayments() {
+	if path := os.Getenv("REFUNDED_PAYMENTS_FILE"); path != "" {
+		refundedPaymentsPath = path
+	}
+
+	data, err := os.ReadFile(refundedPaymentsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Failed to read refunded payments: %v", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry refund
+		//files of older versions hold one plain id per line, all of them finished
+		if !strings.HasPrefix(line, "{") {
+			entry = refund{TransactionId: line, Done: true}
+		} else if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.TransactionId == "" {
+			log.Fatalf("Corrupt line in refunded payments file: %q", line)
+		}
+
+		refundedPayments[entry.TransactionId] = true
+		if entry.Done {
+			delete(pendingRefunds, entry.TransactionId)
+		} else {
+			pendingRefunds[entry.TransactionId] = &entry
+		}
+	}
+	fmt.Printf("Loaded %d refunded payments, %d still to finish\n", len(refundedPayments), len(pendingRefunds))
+}
+
+// function to write a refund to disk, caller holds tpc.mu
+// a failed write is cut off again, so the file never ends in a torn line
+func writeRefund(entry *refund) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(refundedPaymentsPath, os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		file.Truncate(offset)
+		return err
+	}
+	return nil
+}
+
+// function to record a refund for good before any money moves back, caller holds tpc.mu
+// when this fails nothing is undone, so a retry of the payment can still finish it instead
+func recordRefund(entry *refund) error {
+	if err := writeRefund(entry); err != nil {
+		return err
+	}
+	refundedPayments[entry.TransactionId] = true
+	pendingRefunds[entry.TransactionId] = entry
+	return nil
+}
+
+// function to reverse every credit of a refund and give the sender the whole amount back
+// every step is keyed at the bank, so running it again after a failure never moves money twice
+func settleRefund(ctx context.Context, entry *refund) error {
+	for _, c := range entry.Credits {
+		err := reverseDepositTo(ctx, entry.TransactionId, c.Account, c.Amount)
+		if err != nil {
+			return fmt.Errorf("Failed to reverse %.2f credited to %s: %v", c.Amount, c.Account, err)
+		}
+	}
+
+	err := depositTo(ctx, entry.TransactionId+"/refund", entry.SenderId, entry.Amount)
+	if err != nil {
+		return fmt.Errorf("Failed to refund %.2f to %s: %v", entry.Amount, entry.SenderId, err)
+	}
+	return nil
+}
+
+// function to finish a refund, caller holds tpc.mu
+// a refund which can't be finished now stays pending and is tried again by retryPendingRefunds
+func finishRefund(ctx context.Context, entry *refund) error {
+	err := settleRefund(ctx, entry)
+	if err != nil {
+		log.Printf("Refund of %s not finished, will retry: %v", entry.TransactionId, err)
+		return err
+	}
+
+	done := &refund{TransactionId: entry.TransactionId, Done: true}
+	if errWrite := writeRefund(done); errWrite != nil {
+		//finishing again is a no-op at the bank, so it simply stays pending
+		log.Printf("Failed to mark refund of %s done, will retry: %v", entry.TransactionId, errWrite)
+		return errWrite
+	}
+	delete(pendingRefunds, entry.TransactionId)
+
+	//the logger writes the outcome from the event, so it is logged once
+	publishPaymentEvent(eventbus.PaymentRefunded, entry.TransactionId, &pb.TransactionDetails{
+		TransactionId: entry.TransactionId,
+		SenderId:      entry.SenderId,
+		ReceiverId:    entry.ReceiverId,
+		Amount:        entry.Amount,
+		MerchantId:    entry.MerchantId,
+	})
+	return nil
+}
+
+// function to keep trying refunds which could not be finished, runs for the life of the server
+func (tpc *TwoPhaseCommitServer) retryPendingRefunds() {
+	for {
+		time.Sleep(refundRetryInterval)
+
+		tpc.mu.Lock()
+		for _, entry := range pendingRefunds {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			finishRefund(ctx, entry)
+			cancel()
+		}
+		tpc.mu.Unlock()
+	}
+}
+
+// function to refuse a payment already refunded, running it again would credit the receiver
+// with money the sender got back, caller holds tpc.mu
+func checkNotRefunded(transactionId string) error {
+	if refundedPayments[transactionId] {
+		return status.Errorf(codes.FailedPrecondition, "Transaction %s was refunded, it can't run again", transactionId)
+	}
+	return nil
+}
+
+// function to publish how a payment ended, the webhook dispatcher notifies its merchant from the bus
+func publishPaymentEvent(eventType, transactionId string, details *pb.TransactionDetails) {
+	event := &pb.PaymentEvent{Type: eventType, TransactionId: transactionId}
+	//an abort of a payment this coordinator never prepared only knows its id
+	if details != nil {
+		event.SenderId = details.SenderId
+		event.ReceiverId = details.ReceiverId
+		event.Amount = details.Amount
+		event.MerchantId = details.MerchantId
+	}
+
+	err := eventbus.PublishPayment(eventBus, event)
+	if err != nil {
+		fmt.Printf("Failed to publish %s for %s: %v\n", eventType, transactionId, err)
+	}
+}
+
 // search for all available bank servers
 func getAllBankServers() ([]pb.BankServerClient, []string, error) {
-	conn, err := grpc.Dial(bankLoadBalancerAddressHere, grpc.WithInsecure())
+	conn, err := grpc.Dial(bankLoadBalancerAddressHere, transportSecurity, serviceIdentity)
 	if err != nil {
 		return nil, nil, fmt.Errorf("Failed to connect to load balancer: %v", err)
 	}
@@ -59,7 +308,7 @@ func getAllBankServers() ([]pb.BankServerClient, []string, error) {
 	var bankAddresses []string
 
 	for _, server := range bankServers.Servers {
-		bankConn, err := grpc.Dial(server.Address, grpc.WithInsecure())
+		bankConn, err := grpc.Dial(server.Address, transportSecurity, serviceIdentity)
 		if err != nil {
 			log.Printf("Connection not established to this bank: %s", server.Address)
 			continue
@@ -71,81 +320,203 @@ func getAllBankServers() ([]pb.BankServerClient, []string, error) {
 	return bankClients, bankAddresses, nil
 }
 
-// function to ask all bank servers if they are ready to commit
+// function to connect to the bank server owning an account
+func getBankForAccount(accountNumber string) (pb.BankServerClient, *grpc.ClientConn, string, error) {
+	conn, err := grpc.Dial(bankLoadBalancerAddressHere, transportSecurity, serviceIdentity)
+	if err != nil {
+		return nil, nil, "", status.Errorf(codes.Unavailable, "Failed to connect to load balancer: %v", err)
+	}
+	defer conn.Close()
+
+	shard, err := pb.NewBankLoadBalancerClient(conn).GetAccountShard(context.Background(), &pb.AccountRequest{AccountNumber: accountNumber})
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	bankConn, err := grpc.Dial(shard.Address, transportSecurity, serviceIdentity)
+	if err != nil {
+		return nil, nil, "", status.Errorf(codes.Unavailable, "Failed to connect to bank server %s: %v", shard.Address, err)
+	}
+	return pb.NewBankServerClient(bankConn), bankConn, shard.Address, nil
+}
+
+// function to work out who gets what, a fee with nowhere to go fails the payment
+func paymentCredits(req *pb.TransactionDetails) ([]fees.Credit, error) {
+	credits, err := feeSchedule.Credits(req.ReceiverId, req.MerchantId, req.Amount)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return credits, nil
+}
+
+// function to credit an account on its owning bank server
+// the bank applies one credit per reference and account, so a retried commit never pays twice
+func depositTo(ctx context.Context, reference, accountNumber string, amount float64) error {
+	bankClient, conn, _, err := getBankForAccount(accountNumber)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = bankClient.DepositMoney(ctx, &pb.DepositRequest{AccountNumber: accountNumber, Amount: amount, TransactionId: reference})
+	return err
+}
+
+// function to take back a credit of a payment, a credit which never arrived is barred from arriving later
+func reverseDepositTo(ctx context.Context, reference, accountNumber string, amount float64) error {
+	bankClient, conn, _, err := getBankForAccount(accountNumber)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = bankClient.ReverseDeposit(ctx, &pb.DepositRequest{AccountNumber: accountNumber, Amount: amount, TransactionId: reference})
+	return err
+}
+
+// function to ask the bank server of the sender if it is ready to commit
+// a plain no vote means the sender can't cover it, a failure to ask comes back as an error
+// with its grpc code so the gateway knows whether trying again later can help
 func (tpc *TwoPhaseCommitServer) ReadyToCommitTransaction(ctx context.Context, req *pb.TransactionDetails) (*pb.Vote, error) {
 	tpc.mu.Lock()
 	defer tpc.mu.Unlock()
 
-	bankClients, bankAddresses, err := getAllBankServers()
+	if err := checkNotRefunded(req.TransactionId); err != nil {
+		return &pb.Vote{FinalDecision: false}, err
+	}
+	preparedPayments[req.TransactionId] = req
 
+	credits, err := paymentCredits(req)
 	if err != nil {
-		log.Printf("No available bank server ready for transaction: %s", req.TransactionId)
-		return &pb.Vote{FinalDecision: false}, fmt.Errorf("No available bank servers")
+		log.Printf("Can't split transaction %s: %v", req.TransactionId, err)
+		return &pb.Vote{FinalDecision: false}, err
 	}
-	if len(bankClients) == 0 {
+
+	//every account the money goes to must be owned by some bank server
+	for _, c := range credits {
+		_, conn, _, err := getBankForAccount(c.Account)
+		if err != nil {
+			log.Printf("No bank server for account %s of transaction %s: %v", c.Account, req.TransactionId, err)
+			return &pb.Vote{FinalDecision: false}, err
+		}
+		conn.Close()
+	}
+
+	bankClient, conn, bankAddress, err := getBankForAccount(req.SenderId)
+	if err != nil {
 		log.Printf("No available bank server ready for transaction: %s", req.TransactionId)
-		return &pb.Vote{FinalDecision: false}, fmt.Errorf("No available bank servers")
+		return &pb.Vote{FinalDecision: false}, err
 	}
+	defer conn.Close()
 
 	//here setting timeout of 10 seconds
 	timeOutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	for i, bankClient := range bankClients {
-		resp, err := bankClient.HasEnoughMoney(timeOutCtx, &pb.MoneyRequest{
+	resp, err := bankClient.HasEnoughMoney(timeOutCtx, &pb.MoneyRequest{
 
-			AccountNumber: req.SenderId,
-			Amount:        req.Amount,
-		})
+		AccountNumber: req.SenderId,
+		Amount:        req.Amount,
+		TransactionId: req.TransactionId,
+	})
 
-		if err != nil || !resp.Approved {
-			log.Printf("Bank Server %s not ready to commit %s", bankAddresses[i], req.TransactionId)
-			return &pb.Vote{FinalDecision: false}, nil
-		}
+	//errors are passed on as is, so the caller sees which limit was hit or that the bank is down
+	if err != nil {
+		log.Printf("Bank Server %s refused %s: %v", bankAddress, req.TransactionId, err)
+		return &pb.Vote{FinalDecision: false}, err
+	}
+
+	if !resp.Approved {
+		log.Printf("Bank Server %s not ready to commit %s", bankAddress, req.TransactionId)
+		return &pb.Vote{FinalDecision: false}, nil
 	}
 
-	log.Printf("All Bank Servers are ready to commit: %s", req.TransactionId)
+	log.Printf("Bank Server %s is ready to commit: %s", bankAddress, req.TransactionId)
 	return &pb.Vote{FinalDecision: true}, nil
 
 }
 
-// function to commit the transaction
+// function to commit the transaction, the sender is debited and receiver (and platform fee) credited
 func (tpc *TwoPhaseCommitServer) CommitTransaction(ctx context.Context, req *pb.TransactionDetails) (*pb.Response, error) {
 	tpc.mu.Lock()
 	defer tpc.mu.Unlock()
 
-	bankClients, bankAddresses, err := getAllBankServers()
+	if err := checkNotRefunded(req.TransactionId); err != nil {
+		return &pb.Response{Status: "Commit Failed"}, err
+	}
+	delete(preparedPayments, req.TransactionId)
 
+	credits, err := paymentCredits(req)
 	if err != nil {
-		log.Printf("No available bank server available for commiting transaction: %s", req.TransactionId)
-		return &pb.Response{Status: "Commit Failed"}, fmt.Errorf("No available bank servers")
+		return &pb.Response{Status: "Commit Failed"}, err
 	}
 
-	if len(bankClients) == 0 {
+	bankClient, conn, bankAddress, err := getBankForAccount(req.SenderId)
+	if err != nil {
 		log.Printf("No available bank server available for commiting transaction: %s", req.TransactionId)
-		return &pb.Response{Status: "Commit Failed"}, fmt.Errorf("No available bank servers")
+		return &pb.Response{Status: "Commit Failed"}, err
 	}
+	defer conn.Close()
 
-	for i, bankClient := range bankClients {
-		_, err := bankClient.DeductMoney(ctx, &pb.DeductRequest{
-			AccountNumber: req.SenderId,
-			Amount:        req.Amount,
-		})
+	_, err = bankClient.DeductMoney(ctx, &pb.DeductRequest{
+		AccountNumber: req.SenderId,
+		Amount:        req.Amount,
+		TransactionId: req.TransactionId,
+	})
 
+	if err != nil {
+		log.Printf("Bank Server %s commit failed %s", bankAddress, req.TransactionId)
+		return &pb.Response{Status: "Commit Failed"}, err
+	}
+
+	for i, c := range credits {
+		err := depositTo(ctx, req.TransactionId, c.Account, c.Amount)
 		if err != nil {
-			log.Printf("Bank Server %s commit failed %s", bankAddresses[i], req.TransactionId)
-			return &pb.Response{Status: "Commit Failed"}, err
+			log.Printf("Failed to credit %.2f to %s for %s, refunding: %v", c.Amount, c.Account, req.TransactionId, err)
+			return &pb.Response{Status: "Commit Failed"}, refundPayment(ctx, req, credits[:i+1], c.Account)
 		}
+		logTransaction(req.TransactionId, c.Account, c.Amount, c.Status)
 	}
 
 	log.Printf("Commit Successful: %s", req.TransactionId)
 
-	logTransaction(req.TransactionId, "SYSTEM", req.Amount, "Committed")
+	//the logger writes the outcome from the event, so it is logged once
+	publishPaymentEvent(eventbus.PaymentCommitted, req.TransactionId, req)
 
-	return &pb.Response{Status: "Commit Successful!"}, err
+	return &pb.Response{Status: "Commit Successful!"}, nil
 
 }
 
+// function to undo a payment whose credits failed part way, caller holds tpc.mu
+// the credits tried so far are reversed, the failed one too as it may have landed anyway,
+// and the sender gets the whole amount back, so the payment is all or nothing
+// returns the error to hand to the gateway
+func refundPayment(ctx context.Context, req *pb.TransactionDetails, tried []fees.Credit, failedAccount string) error {
+	entry := &refund{
+		TransactionId: req.TransactionId,
+		SenderId:      req.SenderId,
+		ReceiverId:    req.ReceiverId,
+		MerchantId:    req.MerchantId,
+		Amount:        req.Amount,
+	}
+	for _, c := range tried {
+		entry.Credits = append(entry.Credits, refundedCredit{Account: c.Account, Amount: c.Amount})
+	}
+
+	//the debit stays applied at the bank, so a retry would pass prepare and pay out again
+	//unless the refund is on disk before any money moves back
+	if err := recordRefund(entry); err != nil {
+		log.Printf("Failed to record refund of %s, leaving it for a retry to finish: %v", req.TransactionId, err)
+		return status.Errorf(codes.Unavailable, "Failed to credit %s and to record a refund, retry the payment", failedAccount)
+	}
+
+	if err := finishRefund(ctx, entry); err != nil {
+		logTransaction(req.TransactionId, req.SenderId, req.Amount, "Refund Pending")
+		return status.Errorf(codes.Internal, "Failed to credit %s, refund of %.2f to sender is pending", failedAccount, req.Amount)
+	}
+	return status.Errorf(codes.Internal, "Failed to credit %s, %.2f refunded to sender", failedAccount, req.Amount)
+}
+
 // function to abort the transaction
 func (tpc *TwoPhaseCommitServer) AbortTransaction(ctx context.Context, req *pb.TransactionID) (*pb.Response, error) {
 	bankClients, bankAddresses, err := getAllBankServers()
@@ -163,20 +534,45 @@ func (tpc *TwoPhaseCommitServer) AbortTransaction(ctx context.Context, req *pb.T
 
 	log.Printf("Transaction %s aborted", req.TransactionId)
 
-	logTransaction(req.TransactionId, "SYSTEM", 0, "Aborted")
+	tpc.mu.Lock()
+	prepared := preparedPayments[req.TransactionId]
+	delete(preparedPayments, req.TransactionId)
+	refunded := refundedPayments[req.TransactionId]
+	tpc.mu.Unlock()
+	//a refunded payment ended with its refunded event, a late retry being turned away changes nothing
+	//and one going to the offline queue isn't over, the gateway says when it is
+	if !refunded && !req.Retrying {
+		publishPaymentEvent(eventbus.PaymentAborted, req.TransactionId, prepared)
+	}
 
 	return &pb.Response{Status: "Aborted"}, nil
 
 }
 
 func main() {
+	//service tokens can't be signed or checked without the shared secret
+	if err := authee.LoadServiceSecret(); err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	listen, err := net.Listen("tcp", ":50057")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	loadFeeSchedule()
+	loadRefundedPayments()
+	openLogShipper()
+
+	eventBus, err = eventbus.OpenDefault()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	grpcServer := grpc.NewServer(grpc.Creds(tlsutil.ServerCredentials(authee.ServiceTwoPhaseCommit)), grpc.UnaryInterceptor(authee.AuthInterceptor), grpc.StreamInterceptor(authee.StreamAuthInterceptor))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
 	tpServer := &TwoPhaseCommitServer{}
+	go tpServer.retryPendingRefunds()
 
 	pb.RegisterTwoPhaseCommitServer(grpcServer, tpServer)
 
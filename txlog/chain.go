@@ -0,0 +1,104 @@
+package txlog
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// name of the file in the log dir holding the signed checkpoints
+const checkpointFile = "checkpoints.jsonl"
+
+// records between two checkpoints, a checkpoint is also written on rotation and on Close
+const CheckpointEvery = 100
+
+// signed statement of what the log looked like up to a seq
+// anyone with the public key can check it, so the log can't be rewritten
+// up to a checkpoint without the signing key
+type Checkpoint struct {
+	Seq uint64 `json:"seq"`
+	//Hash of the record at Seq, which covers every record before it
+	Hash      string    `json:"hash"`
+	At        time.Time `json:"at"`
+	Signature string    `json:"signature"`
+}
+
+// function to hash a record together with the hash of the one before it
+// the hash is over the record's json with Hash left empty, so it covers PrevHash too
+func hashRecord(record Record) string {
+	record.Hash = ""
+	data, _ := json.Marshal(record)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// function to get the bytes a checkpoint signature is over
+func (c Checkpoint) message() []byte {
+	return []byte(fmt.Sprintf("txlog-checkpoint:%d:%s:%s", c.Seq, c.Hash, c.At.UTC().Format(time.RFC3339Nano)))
+}
+
+// function to sign a checkpoint
+func signCheckpoint(key ed25519.PrivateKey, c Checkpoint) Checkpoint {
+	c.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, c.message()))
+	return c
+}
+
+// function to check the signature of a checkpoint
+func (c Checkpoint) verify(public ed25519.PublicKey) bool {
+	signature, err := base64.StdEncoding.DecodeString(c.Signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(public, c.message(), signature)
+}
+
+// function to load the checkpoint signing key, a new one is made on first use
+// the key is kept hex encoded in path and its public half in path.pub for whoever verifies the log
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		seed, err := hex.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("Invalid transaction log signing key in %s", path)
+		}
+		return ed25519.NewKeyFromSeed(seed), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("Failed to read transaction log signing key: %v", err)
+	}
+
+	public, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(path, []byte(hex.EncodeToString(key.Seed())+"\n"), 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to save transaction log signing key: %v", err)
+	}
+	err = os.WriteFile(path+".pub", []byte(hex.EncodeToString(public)+"\n"), 0644)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to save transaction log public key: %v", err)
+	}
+	return key, nil
+}
+
+// function to load the public key checkpoints are verified with, as written by LoadSigningKey
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read transaction log public key: %v", err)
+	}
+	public, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(public) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("Invalid transaction log public key in %s", path)
+	}
+	return ed25519.PublicKey(public), nil
+}
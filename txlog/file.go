@@ -0,0 +1,114 @@
+package txlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const fileSuffix = ".jsonl"
+
+// one file of the log, its records start at seq base
+type logFile struct {
+	base uint64
+	path string
+	//bytes of whole records in it
+	size int64
+	//LoggedAt of its first and last record, zero while it is empty
+	first time.Time
+	last  time.Time
+}
+
+// function to name a file after the seq of its first record, so names sort in log order
+func fileName(base uint64) string {
+	return fmt.Sprintf("%020d%s", base, fileSuffix)
+}
+
+// function to find the files of the log, oldest first
+func listFiles(dir string) ([]logFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []logFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		base, err := strconv.ParseUint(strings.TrimSuffix(name, fileSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		files = append(files, logFile{base: base, path: filepath.Join(dir, name)})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].base < files[j].base })
+	return files, nil
+}
+
+// function to read the records in the first size bytes of a file, size < 0 reads all of it
+// visit gets each record with the offset and length of its line, an error from visit stops the
+// scan and is returned as is. returns the size of the intact part, as a last line without
+// a newline never finished writing
+func scanFile(path string, size int64, visit func(record Record, offset int64, length int) error) (int64, error) {
+	return scanLines(path, size, func(data []byte, line int, offset int64) error {
+		var record Record
+		err := json.Unmarshal(data, &record)
+		if err != nil {
+			return fmt.Errorf("Corrupt transaction log %s at line %d: %v", path, line, err)
+		}
+		return visit(record, offset, len(data))
+	})
+}
+
+// function to go through the whole lines in the first size bytes of a file, see scanFile
+func scanLines(path string, size int64, visit func(data []byte, line int, offset int64) error) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var source io.Reader = file
+	if size >= 0 {
+		source = io.NewSectionReader(file, 0, size)
+	}
+
+	reader := bufio.NewReader(source)
+	var good int64
+	line := 0
+	for {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return good, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("Failed to read %s: %v", path, err)
+		}
+		line++
+
+		err = visit(data, line, good)
+		if err != nil {
+			return 0, err
+		}
+		good += int64(len(data))
+	}
+}
+
+// function to make a new or removed file in dir survive a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
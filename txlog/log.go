@@ -0,0 +1,436 @@
+package txlog
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// biggest a file gets before the next record goes into a new one
+const DefaultMaxSize = 16 << 20
+
+// how long a file takes records before the next one goes into a new one
+const DefaultMaxAge = 24 * time.Hour
+
+// where a record sits, to read it back without scanning its file
+type location struct {
+	base   uint64
+	offset int64
+	length int
+}
+
+// stops a query scan once the limit is passed
+var errQueryDone = errors.New("query limit reached")
+
+// returned by Append for a record whose EntryId is already in the log
+var ErrDuplicate = errors.New("entry is already in the transaction log")
+
+// transaction log kept as JSON Lines files in a directory
+//
+// every record gets the next sequence number and is synced before Append returns.
+// a new file is started once the active one reaches maxSize, or its first record is
+// older than maxAge, and files are named after the seq of their first record. records
+// of a transaction are found through an index by transaction id rebuilt on Open.
+//
+// every record carries the hash of the one before it, across files, and every
+// CheckpointEvery records the hash of the last one is signed into checkpoints.jsonl,
+// see Verify for what that catches.
+type Log struct {
+	mu      sync.Mutex
+	dir     string
+	maxSize int64
+	maxAge  time.Duration
+
+	//oldest first, the last one is the one being written
+	files  []logFile
+	active *os.File
+
+	nextSeq uint64
+	index   map[string][]location
+	//seq of every record written with an EntryId
+	entries map[string]uint64
+
+	//Hash of the last record, the next one chains onto it
+	head string
+	//signs the checkpoints, checkpointed is the seq of the last one
+	key             ed25519.PrivateKey
+	checkpoints     *os.File
+	checkpointsSize int64
+	checkpointed    uint64
+}
+
+// function to open the log in dir, creating it if needed, and index what is in it
+// maxSize <= 0 and maxAge <= 0 mean DefaultMaxSize and DefaultMaxAge, key signs the checkpoints
+func Open(dir string, maxSize int64, maxAge time.Duration, key ed25519.PrivateKey) (*Log, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create transaction log dir: %v", err)
+	}
+
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list transaction log files: %v", err)
+	}
+
+	l := &Log{
+		dir:     dir,
+		maxSize: maxSize,
+		maxAge:  maxAge,
+		files:   files,
+		nextSeq: 1,
+		index:   make(map[string][]location),
+		entries: make(map[string]uint64),
+		key:     key,
+	}
+
+	for i := range l.files {
+		file := &l.files[i]
+		if file.base > l.nextSeq {
+			l.nextSeq = file.base
+		}
+		good, err := scanFile(file.path, -1, func(record Record, offset int64, length int) error {
+			l.track(file, record, offset, length)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		info, err := os.Stat(file.path)
+		if err != nil {
+			return nil, err
+		}
+		if good < info.Size() {
+			//only the file being written can end in a torn line, older ones were closed whole
+			if i != len(l.files)-1 {
+				return nil, fmt.Errorf("Transaction log %s is cut off at byte %d", file.path, good)
+			}
+			err = os.Truncate(file.path, good)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to repair transaction log %s: %v", file.path, err)
+			}
+		}
+		file.size = good
+	}
+
+	if len(l.files) == 0 {
+		err = l.startFile()
+	} else {
+		l.active, err = os.OpenFile(l.files[len(l.files)-1].path, os.O_WRONLY|os.O_APPEND, 0600)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open transaction log: %v", err)
+	}
+
+	err = l.openCheckpoints()
+	if err != nil {
+		l.active.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// function to open the checkpoint file for appending and find the last checkpoint
+func (l *Log) openCheckpoints() error {
+	path := filepath.Join(l.dir, checkpointFile)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to open transaction log checkpoints: %v", err)
+	}
+
+	good, err := scanLines(path, -1, func(data []byte, line int, offset int64) error {
+		var checkpoint Checkpoint
+		err := json.Unmarshal(data, &checkpoint)
+		if err != nil {
+			return fmt.Errorf("Corrupt transaction log checkpoint at line %d: %v", line, err)
+		}
+		if checkpoint.Seq > l.checkpointed {
+			l.checkpointed = checkpoint.Seq
+		}
+		return nil
+	})
+	if err == nil {
+		//a torn last checkpoint never got signed into place
+		err = file.Truncate(good)
+	}
+	if err == nil {
+		_, err = file.Seek(good, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("Failed to read transaction log checkpoints: %v", err)
+	}
+
+	l.checkpoints = file
+	l.checkpointsSize = good
+	return nil
+}
+
+// function to account for a record that is in file, caller holds mu (or is Open)
+func (l *Log) track(file *logFile, record Record, offset int64, length int) {
+	if file.first.IsZero() {
+		file.first = record.LoggedAt
+	}
+	file.last = record.LoggedAt
+	if record.TransactionId != "" {
+		l.index[record.TransactionId] = append(l.index[record.TransactionId], location{base: file.base, offset: offset, length: length})
+	}
+	if record.EntryId != "" {
+		l.entries[record.EntryId] = record.Seq
+	}
+	if record.Seq >= l.nextSeq {
+		l.nextSeq = record.Seq + 1
+		l.head = record.Hash
+	}
+}
+
+// function to start a new file for the records from nextSeq on, caller holds mu
+func (l *Log) startFile() error {
+	path := filepath.Join(l.dir, fileName(l.nextSeq))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	err = syncDir(l.dir)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	l.active = file
+	l.files = append(l.files, logFile{base: l.nextSeq, path: path})
+	return nil
+}
+
+// function to close the active file and go on in a new one, caller holds mu
+func (l *Log) rotate() error {
+	//a failed checkpoint is tried again with the next one
+	l.checkpoint()
+
+	err := l.active.Sync()
+	if err == nil {
+		err = l.active.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to close transaction log file: %v", err)
+	}
+
+	err = l.startFile()
+	if err != nil {
+		return fmt.Errorf("Failed to start transaction log file: %v", err)
+	}
+	return nil
+}
+
+// function to write a record, it gets its seq and LoggedAt here and is synced before returning
+// a record whose EntryId was written before is not written again, that gives ErrDuplicate
+// together with the seq it has in the log
+func (l *Log) Append(record Record) (Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if seq, exists := l.entries[record.EntryId]; exists && record.EntryId != "" {
+		record.Seq = seq
+		return record, ErrDuplicate
+	}
+
+	now := time.Now().UTC()
+	active := &l.files[len(l.files)-1]
+	if active.size > 0 && (active.size >= l.maxSize || now.Sub(active.first) >= l.maxAge) {
+		err := l.rotate()
+		if err != nil {
+			return Record{}, err
+		}
+		active = &l.files[len(l.files)-1]
+	}
+
+	record.Seq = l.nextSeq
+	record.LoggedAt = now
+	record.PrevHash = l.head
+	record.Hash = hashRecord(record)
+	data, err := json.Marshal(record)
+	if err != nil {
+		return Record{}, err
+	}
+	data = append(data, '\n')
+
+	_, err = l.active.Write(data)
+	if err != nil {
+		//a partly written line would be glued onto the next record
+		l.active.Truncate(active.size)
+		return Record{}, fmt.Errorf("Failed to write transaction log: %v", err)
+	}
+	err = l.active.Sync()
+	if err != nil {
+		return Record{}, fmt.Errorf("Failed to sync transaction log: %v", err)
+	}
+
+	l.track(active, record, active.size, len(data))
+	active.size += int64(len(data))
+
+	if record.Seq-l.checkpointed >= CheckpointEvery {
+		//the record is safe either way, a failed checkpoint is tried again on the next append
+		l.checkpoint()
+	}
+	return record, nil
+}
+
+// function to sign the current head into a checkpoint, if anything was logged since the last one
+func (l *Log) Checkpoint() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.checkpoint()
+}
+
+// function to write a checkpoint of the last record, caller holds mu
+func (l *Log) checkpoint() error {
+	last := l.nextSeq - 1
+	if last == 0 || last <= l.checkpointed {
+		return nil
+	}
+
+	checkpoint := signCheckpoint(l.key, Checkpoint{Seq: last, Hash: l.head, At: time.Now().UTC()})
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	_, err = l.checkpoints.Write(data)
+	if err == nil {
+		err = l.checkpoints.Sync()
+	}
+	if err != nil {
+		l.checkpoints.Truncate(l.checkpointsSize)
+		l.checkpoints.Seek(l.checkpointsSize, io.SeekStart)
+		return fmt.Errorf("Failed to write transaction log checkpoint: %v", err)
+	}
+	l.checkpointsSize += int64(len(data))
+	l.checkpointed = last
+	return nil
+}
+
+// function to find records matching the filter, oldest first
+// more is true when the limit cut the answer short
+func (l *Log) Query(filter Filter) ([]Record, bool, error) {
+	//appends only ever add after the sizes seen here, so the files are read without the lock
+	l.mu.Lock()
+	files := append([]logFile(nil), l.files...)
+	var locations []location
+	if filter.TransactionId != "" {
+		locations = append(locations, l.index[filter.TransactionId]...)
+	}
+	l.mu.Unlock()
+
+	var records []Record
+	more := false
+	collect := func(record Record) error {
+		if !filter.matches(record) {
+			return nil
+		}
+		if filter.Limit > 0 && len(records) == filter.Limit {
+			more = true
+			return errQueryDone
+		}
+		records = append(records, record)
+		return nil
+	}
+
+	var err error
+	if filter.TransactionId != "" {
+		err = l.readLocations(locations, collect)
+	} else {
+		err = scanFiles(files, filter, collect)
+	}
+	if err != nil && !errors.Is(err, errQueryDone) {
+		return nil, false, err
+	}
+	return records, more, nil
+}
+
+// function to read the records in every file that can hold a match of the filter
+func scanFiles(files []logFile, filter Filter, collect func(Record) error) error {
+	for i, file := range files {
+		if file.size == 0 || !filter.inRange(file.first, file.last) {
+			continue
+		}
+		//every record of this file is before the next one's base
+		if i+1 < len(files) && files[i+1].base <= filter.AfterSeq+1 {
+			continue
+		}
+		_, err := scanFile(file.path, file.size, func(record Record, offset int64, length int) error {
+			return collect(record)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// function to read records straight from where the index says they are
+func (l *Log) readLocations(locations []location, collect func(Record) error) error {
+	opened := make(map[uint64]*os.File)
+	defer func() {
+		for _, file := range opened {
+			file.Close()
+		}
+	}()
+
+	for _, loc := range locations {
+		file, exists := opened[loc.base]
+		if !exists {
+			var err error
+			file, err = os.Open(filepath.Join(l.dir, fileName(loc.base)))
+			if err != nil {
+				return fmt.Errorf("Failed to read transaction log: %v", err)
+			}
+			opened[loc.base] = file
+		}
+
+		data := make([]byte, loc.length)
+		_, err := file.ReadAt(data, loc.offset)
+		if err != nil {
+			return fmt.Errorf("Failed to read transaction log: %v", err)
+		}
+		var record Record
+		err = json.Unmarshal(data, &record)
+		if err != nil {
+			return fmt.Errorf("Corrupt transaction log %s at byte %d: %v", fileName(loc.base), loc.offset, err)
+		}
+		err = collect(record)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// function to checkpoint what was logged and close the files being written
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	err := l.checkpoint()
+	errClose := l.checkpoints.Close()
+	if err == nil {
+		err = errClose
+	}
+	errClose = l.active.Close()
+	if err == nil {
+		err = errClose
+	}
+	return err
+}
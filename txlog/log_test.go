@@ -0,0 +1,191 @@
+package txlog
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func openLog(t *testing.T, dir string, maxSize int64, key ed25519.PrivateKey) *Log {
+	t.Helper()
+	l, err := Open(dir, maxSize, 0, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func mustAppend(t *testing.T, l *Log, record Record) Record {
+	t.Helper()
+	written, err := l.Append(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return written
+}
+
+// function to write n records of transactions tx-0 .. tx-(n%3), alternating committed and aborted
+func fill(t *testing.T, l *Log, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		status := "Committed"
+		if i%2 == 1 {
+			status = "Aborted"
+		}
+		mustAppend(t, l, Record{TransactionId: fmt.Sprintf("tx-%d", i%3), ClientId: "c", Amount: float64(i), Status: status})
+	}
+}
+
+func seqsOf(records []Record) []uint64 {
+	seqs := make([]uint64, 0, len(records))
+	for _, record := range records {
+		seqs = append(seqs, record.Seq)
+	}
+	return seqs
+}
+
+func TestAppendNumbersAndRotates(t *testing.T) {
+	dir := t.TempDir()
+	l := openLog(t, dir, 300, newKey(t))
+	defer l.Close()
+	fill(t, l, 20)
+
+	files, err := listFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) < 3 {
+		t.Fatalf("%d files after 20 records with a 300 byte limit, want rotation", len(files))
+	}
+	//files are named after their first record
+	records, _, err := l.Query(Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, record := range records {
+		if record.Seq != uint64(i+1) {
+			t.Fatalf("record %d has seq %d", i, record.Seq)
+		}
+	}
+	for _, file := range files {
+		first, _, err := l.Query(Filter{AfterSeq: file.base - 1, Limit: 1})
+		if err != nil || len(first) != 1 || first[0].Seq != file.base {
+			t.Fatalf("file %s doesn't start at its base: %v %v", file.path, seqsOf(first), err)
+		}
+	}
+}
+
+func TestQueryFilters(t *testing.T) {
+	l := openLog(t, t.TempDir(), 300, newKey(t))
+	defer l.Close()
+	fill(t, l, 12)
+
+	cases := map[string]struct {
+		filter Filter
+		want   []uint64
+		more   bool
+	}{
+		"by transaction":  {Filter{TransactionId: "tx-1"}, []uint64{2, 5, 8, 11}, false},
+		"status any case": {Filter{TransactionId: "tx-1", Status: "aborted"}, []uint64{2, 8}, false},
+		"limit":           {Filter{Status: "Committed", Limit: 2}, []uint64{1, 3}, true},
+		"page on":         {Filter{Status: "Committed", AfterSeq: 3, Limit: 2}, []uint64{5, 7}, true},
+		"last page":       {Filter{Status: "Committed", AfterSeq: 9}, []uint64{11}, false},
+		"no such client":  {Filter{ClientId: "nobody"}, []uint64{}, false},
+		"future":          {Filter{From: time.Now().Add(time.Hour)}, []uint64{}, false},
+	}
+	for name, c := range cases {
+		records, more, err := l.Query(c.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := seqsOf(records); fmt.Sprint(got) != fmt.Sprint(c.want) || more != c.more {
+			t.Errorf("%s: got %v more=%v, want %v more=%v", name, got, more, c.want, c.more)
+		}
+	}
+}
+
+func TestDuplicateEntryIsNotWrittenAgain(t *testing.T) {
+	dir := t.TempDir()
+	key := newKey(t)
+	l := openLog(t, dir, 0, key)
+	first := mustAppend(t, l, Record{TransactionId: "tx-1", Status: "Committed", EntryId: "e-1"})
+	l.Close()
+
+	//the index of entry ids is rebuilt on open, a resend after a restart is still caught
+	l = openLog(t, dir, 0, key)
+	defer l.Close()
+	again, err := l.Append(Record{TransactionId: "tx-1", Status: "Committed", EntryId: "e-1"})
+	if err != ErrDuplicate || again.Seq != first.Seq {
+		t.Fatalf("resent entry got seq %d and %v, want seq %d and ErrDuplicate", again.Seq, err, first.Seq)
+	}
+	if records, _, _ := l.Query(Filter{}); len(records) != 1 {
+		t.Fatalf("%d records after a resend, want 1", len(records))
+	}
+}
+
+func TestTornTailIsCutOnOpen(t *testing.T) {
+	dir := t.TempDir()
+	key := newKey(t)
+	l := openLog(t, dir, 0, key)
+	fill(t, l, 3)
+	l.Close()
+
+	//a crash half way through the next record
+	files, _ := listFiles(dir)
+	file, err := os.OpenFile(files[len(files)-1].path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"seq":4,"transactionId":"tx-`)
+	file.Close()
+
+	l = openLog(t, dir, 0, key)
+	defer l.Close()
+	record := mustAppend(t, l, Record{TransactionId: "tx-9", Status: "Committed"})
+	if record.Seq != 4 {
+		t.Fatalf("record after the torn tail got seq %d, want 4", record.Seq)
+	}
+	report, err := l.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() || report.Records != 4 {
+		t.Fatalf("log after repair: %d records, problems %v", report.Records, report.Problems)
+	}
+}
+
+func TestTornLineInAClosedFileIsAnError(t *testing.T) {
+	dir := t.TempDir()
+	key := newKey(t)
+	l := openLog(t, dir, 200, key)
+	fill(t, l, 6)
+	l.Close()
+
+	files, _ := listFiles(dir)
+	if len(files) < 2 {
+		t.Fatal("no rotation to test with")
+	}
+	file, err := os.OpenFile(filepath.Join(dir, fileName(files[0].base)), os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"seq":`)
+	file.Close()
+
+	if _, err := Open(dir, 200, 0, key); err == nil {
+		t.Fatal("a closed file cut off was opened as if nothing happened")
+	}
+}
@@ -0,0 +1,69 @@
+package txlog
+
+import (
+	"strings"
+	"time"
+)
+
+// one line of the transaction log
+type Record struct {
+	//numbered by the log, one higher than the record before
+	Seq uint64 `json:"seq"`
+	//set by the log when the record is written, time queries and rotation go by it
+	LoggedAt      time.Time `json:"loggedAt"`
+	TransactionId string    `json:"transactionId"`
+	ClientId      string    `json:"clientId"`
+	Amount        float64   `json:"amount"`
+	Status        string    `json:"status"`
+	//as sent by the service logging it
+	Timestamp string `json:"timestamp,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	//id given by the sender, a record with an id already in the log is not written again
+	EntryId string `json:"entryId,omitempty"`
+	//Hash of the record before it, empty for the first one, so no record can be changed,
+	//dropped or moved without breaking the chain from there on
+	PrevHash string `json:"prevHash"`
+	Hash     string `json:"hash"`
+}
+
+// what to look for in the log, empty fields match everything
+type Filter struct {
+	TransactionId string
+	ClientId      string
+	Status        string
+	//on LoggedAt, both ends included
+	From time.Time
+	To   time.Time
+	//only records after this seq, to page on from the last one of a previous answer
+	AfterSeq uint64
+	//at most this many records, oldest first, 0 means no limit
+	Limit int
+}
+
+// function to check one record against the filter
+func (f Filter) matches(record Record) bool {
+	if record.Seq <= f.AfterSeq {
+		return false
+	}
+	if f.TransactionId != "" && record.TransactionId != f.TransactionId {
+		return false
+	}
+	if f.ClientId != "" && record.ClientId != f.ClientId {
+		return false
+	}
+	if f.Status != "" && !strings.EqualFold(record.Status, f.Status) {
+		return false
+	}
+	return f.inRange(record.LoggedAt, record.LoggedAt)
+}
+
+// function to check whether anything logged between first and last can be in the time range
+func (f Filter) inRange(first, last time.Time) bool {
+	if !f.From.IsZero() && last.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && first.After(f.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package txlog
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// something wrong found in the log, Line is within File
+type Problem struct {
+	Seq    uint64
+	File   string
+	Line   int
+	Reason string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d seq %d: %s", p.File, p.Line, p.Seq, p.Reason)
+}
+
+// what Verify found
+type Report struct {
+	Records uint64
+	LastSeq uint64
+	//Hash of the last record
+	Head string
+	//checkpoints with a valid signature that match the log
+	Checkpoints int
+	Problems    []Problem
+}
+
+// function to tell whether the log checked out
+func (r Report) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// function to check the log in dir against its chain and checkpoints, without opening it for writing
+//
+// a changed record no longer matches its own hash, a deleted or moved one shows up as a
+// gap or step back in seq and a prevHash that doesn't match, and records deleted at the
+// end are caught by a checkpoint past the end of the log. recomputing the hashes after an
+// edit still leaves every checkpoint from there on not matching, and those can't be
+// signed again without the signing key
+func Verify(dir string, public ed25519.PublicKey) (Report, error) {
+	files, err := listFiles(dir)
+	if err != nil {
+		return Report{}, err
+	}
+	for i := range files {
+		files[i].size = -1
+	}
+	return verify(dir, files, -1, public)
+}
+
+// function to check the log while it is being written, up to what was written when called
+func (l *Log) Verify() (Report, error) {
+	l.mu.Lock()
+	files := append([]logFile(nil), l.files...)
+	checkpointsSize := l.checkpointsSize
+	public := l.key.Public().(ed25519.PublicKey)
+	l.mu.Unlock()
+
+	return verify(l.dir, files, checkpointsSize, public)
+}
+
+// one line of the checkpoint file, kept to hold against the chain once it is walked
+type checkpointLine struct {
+	checkpoint Checkpoint
+	line       int
+}
+
+// function to read the checkpoints and walk the chain, see Verify
+func verify(dir string, files []logFile, checkpointsSize int64, public ed25519.PublicKey) (Report, error) {
+	report := Report{}
+
+	var checkpoints []checkpointLine
+	//hashes the chain had at checkpointed seqs
+	hashes := make(map[uint64]string)
+	_, err := scanLines(filepath.Join(dir, checkpointFile), checkpointsSize, func(data []byte, line int, offset int64) error {
+		var checkpoint Checkpoint
+		err := json.Unmarshal(data, &checkpoint)
+		if err != nil {
+			report.Problems = append(report.Problems, Problem{File: checkpointFile, Line: line, Reason: fmt.Sprintf("not a valid checkpoint: %v", err)})
+			return nil
+		}
+		if !checkpoint.verify(public) {
+			report.Problems = append(report.Problems, Problem{Seq: checkpoint.Seq, File: checkpointFile, Line: line, Reason: "checkpoint signature is not valid"})
+			return nil
+		}
+		checkpoints = append(checkpoints, checkpointLine{checkpoint: checkpoint, line: line})
+		hashes[checkpoint.Seq] = ""
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) && len(files) > 0 {
+		report.Problems = append(report.Problems, Problem{File: checkpointFile, Reason: "checkpoint file is missing"})
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return report, err
+	}
+
+	expected := uint64(1)
+	prev := ""
+	for _, file := range files {
+		name := filepath.Base(file.path)
+		first := true
+		_, err := scanLines(file.path, file.size, func(data []byte, line int, offset int64) error {
+			problem := func(seq uint64, format string, args ...interface{}) {
+				report.Problems = append(report.Problems, Problem{Seq: seq, File: name, Line: line, Reason: fmt.Sprintf(format, args...)})
+			}
+
+			var record Record
+			err := json.Unmarshal(data, &record)
+			if err != nil {
+				problem(expected, "not a valid record: %v", err)
+				return nil
+			}
+			report.Records++
+
+			if first && record.Seq != file.base {
+				problem(record.Seq, "first record of a file named for seq %d", file.base)
+			}
+			first = false
+			switch {
+			case record.Seq == expected+1:
+				problem(record.Seq, "record %d is missing", expected)
+			case record.Seq > expected:
+				problem(record.Seq, "records %d to %d are missing", expected, record.Seq-1)
+			case record.Seq < expected:
+				problem(record.Seq, "out of order, comes after seq %d", expected-1)
+			}
+			if record.PrevHash != prev {
+				problem(record.Seq, "prevHash does not match the record before it")
+			}
+			if record.Hash != hashRecord(record) {
+				problem(record.Seq, "record was modified, its hash does not match its content")
+			}
+
+			if _, checkpointed := hashes[record.Seq]; checkpointed {
+				hashes[record.Seq] = record.Hash
+			}
+			prev = record.Hash
+			if record.Seq >= expected {
+				expected = record.Seq + 1
+				report.LastSeq = record.Seq
+			}
+			return nil
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+	report.Head = prev
+
+	for _, c := range checkpoints {
+		problem := Problem{Seq: c.checkpoint.Seq, File: checkpointFile, Line: c.line}
+		hash := hashes[c.checkpoint.Seq]
+		switch {
+		case c.checkpoint.Seq > report.LastSeq:
+			problem.Reason = fmt.Sprintf("log ends at seq %d, records up to the checkpoint were deleted", report.LastSeq)
+		case hash == "":
+			problem.Reason = "checkpointed record is missing"
+		case hash != c.checkpoint.Hash:
+			problem.Reason = "log does not match the checkpoint, records up to it were changed"
+		default:
+			report.Checkpoints++
+			continue
+		}
+		report.Problems = append(report.Problems, problem)
+	}
+	return report, nil
+}
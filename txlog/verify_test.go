@@ -0,0 +1,169 @@
+package txlog
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// function to write a closed log of n records with a checkpoint at the end, returning its dir and public key
+func closedLog(t *testing.T, n int) (string, ed25519.PublicKey) {
+	t.Helper()
+	dir := t.TempDir()
+	key := newKey(t)
+	l := openLog(t, dir, 0, key)
+	fill(t, l, n)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return dir, key.Public().(ed25519.PublicKey)
+}
+
+// function to rewrite the lines of a file in the log dir
+func rewrite(t *testing.T, path string, edit func(lines [][]byte) [][]byte) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := edit(bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n")))
+	data = append(bytes.Join(lines, []byte("\n")), '\n')
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// function to change the record on line i, rehash sets its hash to match the new content
+func editRecord(t *testing.T, dir string, i int, rehash bool, change func(record *Record)) {
+	t.Helper()
+	rewrite(t, filepath.Join(dir, fileName(1)), func(lines [][]byte) [][]byte {
+		var record Record
+		if err := json.Unmarshal(lines[i], &record); err != nil {
+			t.Fatal(err)
+		}
+		change(&record)
+		if rehash {
+			record.Hash = hashRecord(record)
+		}
+		lines[i], _ = json.Marshal(record)
+		return lines
+	})
+}
+
+func verifyDir(t *testing.T, dir string, public ed25519.PublicKey) Report {
+	t.Helper()
+	report, err := Verify(dir, public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+// function to fail unless one of the problems has reason in it
+func wantProblem(t *testing.T, report Report, reason string) {
+	t.Helper()
+	if report.OK() {
+		t.Fatalf("log passed verification, want %q", reason)
+	}
+	for _, problem := range report.Problems {
+		if strings.Contains(problem.Reason, reason) {
+			return
+		}
+	}
+	t.Fatalf("problems %v, want %q", report.Problems, reason)
+}
+
+func TestCleanLogVerifies(t *testing.T) {
+	dir := t.TempDir()
+	key := newKey(t)
+	l := openLog(t, dir, 400, key)
+	fill(t, l, CheckpointEvery+5)
+	l.Close()
+
+	report := verifyDir(t, dir, key.Public().(ed25519.PublicKey))
+	if !report.OK() {
+		t.Fatalf("clean log has problems %v", report.Problems)
+	}
+	if report.Records != CheckpointEvery+5 || report.LastSeq != CheckpointEvery+5 {
+		t.Fatalf("report has %d records up to seq %d", report.Records, report.LastSeq)
+	}
+	//one every CheckpointEvery records, on each rotation and on close
+	if report.Checkpoints < 3 {
+		t.Fatalf("only %d checkpoints verified", report.Checkpoints)
+	}
+}
+
+func TestModifiedRecordIsCaught(t *testing.T) {
+	dir, public := closedLog(t, 5)
+	editRecord(t, dir, 2, false, func(record *Record) { record.Amount = 1000 })
+	wantProblem(t, verifyDir(t, dir, public), "its hash does not match its content")
+}
+
+func TestRehashedRecordBreaksTheChain(t *testing.T) {
+	dir, public := closedLog(t, 5)
+	editRecord(t, dir, 2, true, func(record *Record) { record.Amount = 1000 })
+	wantProblem(t, verifyDir(t, dir, public), "prevHash does not match the record before it")
+}
+
+func TestRewrittenTailIsCaughtByTheCheckpoint(t *testing.T) {
+	//the last record changed and rehashed leaves no later record to break, only the checkpoint knows
+	dir, public := closedLog(t, 5)
+	editRecord(t, dir, 4, true, func(record *Record) { record.Amount = 1000 })
+	wantProblem(t, verifyDir(t, dir, public), "log does not match the checkpoint")
+}
+
+func TestDeletedRecordIsCaught(t *testing.T) {
+	dir, public := closedLog(t, 5)
+	rewrite(t, filepath.Join(dir, fileName(1)), func(lines [][]byte) [][]byte {
+		return append(lines[:2:2], lines[3:]...)
+	})
+	wantProblem(t, verifyDir(t, dir, public), "record 3 is missing")
+}
+
+func TestDeletedTailIsCaughtByTheCheckpoint(t *testing.T) {
+	dir, public := closedLog(t, 5)
+	rewrite(t, filepath.Join(dir, fileName(1)), func(lines [][]byte) [][]byte {
+		return lines[:3]
+	})
+	wantProblem(t, verifyDir(t, dir, public), "log ends at seq 3")
+}
+
+func TestForgedCheckpointIsCaught(t *testing.T) {
+	dir, public := closedLog(t, 5)
+	//the tail is rewritten and a checkpoint claimed for it, without the signing key
+	editRecord(t, dir, 4, true, func(record *Record) { record.Amount = 1000 })
+	rewrite(t, filepath.Join(dir, checkpointFile), func(lines [][]byte) [][]byte {
+		var checkpoint Checkpoint
+		if err := json.Unmarshal(lines[len(lines)-1], &checkpoint); err != nil {
+			t.Fatal(err)
+		}
+		records, err := os.ReadFile(filepath.Join(dir, fileName(1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var last Record
+		all := bytes.Split(bytes.TrimSpace(records), []byte("\n"))
+		json.Unmarshal(all[len(all)-1], &last)
+		checkpoint.Hash = last.Hash
+		lines[len(lines)-1], _ = json.Marshal(checkpoint)
+		return lines
+	})
+	wantProblem(t, verifyDir(t, dir, public), "checkpoint signature is not valid")
+
+	//and a checkpoint signed by another key is no better
+	other := newKey(t).Public().(ed25519.PublicKey)
+	clean, _ := closedLog(t, 5)
+	wantProblem(t, verifyDir(t, clean, other), "checkpoint signature is not valid")
+}
+
+func TestMissingCheckpointFileIsCaught(t *testing.T) {
+	dir, public := closedLog(t, 5)
+	if err := os.Remove(filepath.Join(dir, checkpointFile)); err != nil {
+		t.Fatal(err)
+	}
+	wantProblem(t, verifyDir(t, dir, public), "checkpoint file is missing")
+}
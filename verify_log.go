@@ -0,0 +1,37 @@
+package main
+
+import (
+	"assignment_2/txlog"
+	"flag"
+	"fmt"
+	"log"
+)
+
+// checks the transaction log files against their hash chain and signed checkpoints
+// it only reads the files, so it can run on a copy or next to a running logger
+//
+//	go run verify_log.go                                         check ./transaction_log
+//	go run verify_log.go -dir backup/transaction_log -pub key.pub check a copy
+func main() {
+	dir := flag.String("dir", "transaction_log", "directory of the transaction log")
+	publicKeyFile := flag.String("pub", "transaction_log.key.pub", "public key the checkpoints were signed with")
+	flag.Parse()
+
+	public, err := txlog.LoadPublicKey(*publicKeyFile)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	report, err := txlog.Verify(*dir, public)
+	if err != nil {
+		log.Fatalf("Failed to verify transaction log: %v", err)
+	}
+
+	for _, problem := range report.Problems {
+		fmt.Println(problem)
+	}
+	if !report.OK() {
+		log.Fatalf("transaction log has %d problems", len(report.Problems))
+	}
+	fmt.Printf("transaction log ok: %d records up to seq %d, %d checkpoints, head %s\n", report.Records, report.LastSeq, report.Checkpoints, report.Head)
+}
@@ -0,0 +1,406 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// payment events merchants can subscribe to
+const (
+	EventPaymentCommitted = "payment.committed"
+	EventPaymentAborted   = "payment.aborted"
+	EventPaymentRefunded  = "payment.refunded"
+)
+
+var knownEvents = map[string]bool{
+	EventPaymentCommitted: true,
+	EventPaymentAborted:   true,
+	EventPaymentRefunded:  true,
+}
+
+// states of one delivery, delivered ones are dropped right away
+const (
+	StatusPending = "pending"
+	StatusDead    = "dead"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// url of a merchant receiving events
+type Endpoint struct {
+	Id         string
+	MerchantId string
+	URL        string
+	EventTypes []string
+	//shared with the merchant once, signs every delivery
+	Secret    string
+	CreatedAt time.Time
+}
+
+// the payment an event is about
+type Payment struct {
+	TransactionId string  `json:"transactionId"`
+	SenderId      string  `json:"senderId"`
+	ReceiverId    string  `json:"receiverId"`
+	Amount        float64 `json:"amount"`
+	MerchantId    string  `json:"merchantId,omitempty"`
+}
+
+// body of a delivery
+type Event struct {
+	Id        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"createdAt"`
+	Data      Payment   `json:"data"`
+}
+
+// one event on its way to one endpoint
+type Delivery struct {
+	Id         string
+	EventId    string
+	EventType  string
+	EndpointId string
+	MerchantId string
+	Status     string
+	Attempts   int
+	LastError  string
+	//zero once delivered or dead
+	NextAttempt time.Time
+	body        []byte
+}
+
+// retry settings, attempts are spaced BaseBackoff, 2*BaseBackoff ... capped at MaxBackoff
+type Options struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Timeout     time.Duration
+}
+
+func DefaultOptions() Options {
+	return Options{MaxAttempts: 6, BaseBackoff: time.Second, MaxBackoff: 5 * time.Minute, Timeout: 10 * time.Second}
+}
+
+// sends signed events to merchant endpoints and retries them in the background
+// NewDispatcher keeps its state in memory only, OpenDispatcher in a file that survives a restart
+type Dispatcher struct {
+	mu         sync.Mutex
+	options    Options
+	client     *http.Client
+	endpoints  map[string]*Endpoint
+	deliveries map[string]*Delivery
+	//state file written on every change, empty keeps everything in memory
+	path string
+}
+
+func NewDispatcher(options Options) *Dispatcher {
+	return &Dispatcher{
+		options:    options,
+		client:     &http.Client{Timeout: options.Timeout},
+		endpoints:  make(map[string]*Endpoint),
+		deliveries: make(map[string]*Delivery),
+	}
+}
+
+func randomId(prefix string) string {
+	raw := make([]byte, 12)
+	_, err := rand.Read(raw)
+	if err != nil {
+		//fall back to the clock, ids only have to be unique
+		return prefix + strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return prefix + hex.EncodeToString(raw)
+}
+
+// function to register an endpoint, eventTypes empty means every event
+func (d *Dispatcher) AddEndpoint(merchantId, rawURL string, eventTypes []string) (*Endpoint, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return nil, fmt.Errorf("Webhook url must be an absolute http(s) url")
+	}
+	for _, eventType := range eventTypes {
+		if !knownEvents[eventType] {
+			return nil, fmt.Errorf("Unknown event type %q", eventType)
+		}
+	}
+
+	endpoint := &Endpoint{
+		Id:         randomId("we_"),
+		MerchantId: merchantId,
+		URL:        rawURL,
+		EventTypes: eventTypes,
+		Secret:     randomId("whsec_"),
+		CreatedAt:  time.Now(),
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.endpoints[endpoint.Id] = endpoint
+	err = d.saveLocked()
+	if err != nil {
+		delete(d.endpoints, endpoint.Id)
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// function to remove an endpoint of a merchant, deliveries already queued still go out
+func (d *Dispatcher) RemoveEndpoint(merchantId, endpointId string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	endpoint, exists := d.endpoints[endpointId]
+	if !exists || endpoint.MerchantId != merchantId {
+		return ErrNotFound
+	}
+	delete(d.endpoints, endpointId)
+	err := d.saveLocked()
+	if err != nil {
+		d.endpoints[endpointId] = endpoint
+		return err
+	}
+	return nil
+}
+
+// function to list endpoints of a merchant, secrets blanked out
+func (d *Dispatcher) Endpoints(merchantId string) []Endpoint {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	list := []Endpoint{}
+	for _, endpoint := range d.endpoints {
+		if endpoint.MerchantId == merchantId {
+			copied := *endpoint
+			copied.Secret = ""
+			list = append(list, copied)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
+
+func (e *Endpoint) wants(eventType string) bool {
+	if len(e.EventTypes) == 0 {
+		return true
+	}
+	for _, wanted := range e.EventTypes {
+		if wanted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// function to queue an event for every endpoint of its merchant that wants it, returns how many
+// the deliveries are saved before it returns, an event id already queued is not queued again
+// and an empty one gets a random id
+func (d *Dispatcher) Publish(eventId, eventType string, payment Payment) (int, error) {
+	if !knownEvents[eventType] {
+		return 0, fmt.Errorf("Unknown event type %q", eventType)
+	}
+	if payment.MerchantId == "" {
+		return 0, nil
+	}
+	if eventId == "" {
+		eventId = randomId("evt_")
+	}
+
+	event := Event{Id: eventId, Type: eventType, CreatedAt: time.Now().UTC(), Data: payment}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, delivery := range d.deliveries {
+		if delivery.EventId == eventId {
+			return 0, nil
+		}
+	}
+
+	var queued []*Delivery
+	for _, endpoint := range d.endpoints {
+		if endpoint.MerchantId != payment.MerchantId || !endpoint.wants(eventType) {
+			continue
+		}
+		delivery := &Delivery{
+			Id:         randomId("whd_"),
+			EventId:    event.Id,
+			EventType:  eventType,
+			EndpointId: endpoint.Id,
+			MerchantId: endpoint.MerchantId,
+			Status:     StatusPending,
+			body:       body,
+		}
+		d.deliveries[delivery.Id] = delivery
+		queued = append(queued, delivery)
+	}
+	if len(queued) == 0 {
+		return 0, nil
+	}
+
+	err = d.saveLocked()
+	if err != nil {
+		for _, delivery := range queued {
+			delete(d.deliveries, delivery.Id)
+		}
+		return 0, err
+	}
+	for _, delivery := range queued {
+		d.scheduleLocked(delivery, 0)
+	}
+	return len(queued), nil
+}
+
+// function to run the next attempt after wait, caller holds mu
+func (d *Dispatcher) scheduleLocked(delivery *Delivery, wait time.Duration) {
+	delivery.NextAttempt = time.Now().Add(wait)
+	id := delivery.Id
+	time.AfterFunc(wait, func() { d.attempt(id) })
+}
+
+// function to wait before the given retry, doubling each time
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.options.BaseBackoff
+	for i := 1; i < attempts && wait < d.options.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > d.options.MaxBackoff {
+		wait = d.options.MaxBackoff
+	}
+	return wait
+}
+
+// function to make one delivery attempt, runs on its own timer goroutine
+func (d *Dispatcher) attempt(deliveryId string) {
+	d.mu.Lock()
+	delivery, exists := d.deliveries[deliveryId]
+	if !exists || delivery.Status != StatusPending {
+		d.mu.Unlock()
+		return
+	}
+	endpoint, exists := d.endpoints[delivery.EndpointId]
+	if !exists {
+		delivery.Status = StatusDead
+		delivery.LastError = "endpoint removed"
+		delivery.NextAttempt = time.Time{}
+		d.saveStateLocked()
+		d.mu.Unlock()
+		return
+	}
+	targetURL, secret, body := endpoint.URL, endpoint.Secret, delivery.body
+	eventType := delivery.EventType
+	d.mu.Unlock()
+
+	err := d.post(targetURL, secret, deliveryId, eventType, body)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	defer d.saveStateLocked()
+
+	delivery.Attempts++
+	if err == nil {
+		//only dead letters are kept around for replay
+		delete(d.deliveries, deliveryId)
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= d.options.MaxAttempts {
+		delivery.Status = StatusDead
+		delivery.NextAttempt = time.Time{}
+		fmt.Printf("Webhook delivery %s dead after %d attempts: %v\n", delivery.Id, delivery.Attempts, err)
+		return
+	}
+	d.scheduleLocked(delivery, d.backoff(delivery.Attempts))
+}
+
+// function to save after an attempt, caller holds mu
+// a failed save costs no delivery, the old state has it pending and sends it once more after a restart
+func (d *Dispatcher) saveStateLocked() {
+	err := d.saveLocked()
+	if err != nil {
+		fmt.Printf("Failed to save webhook deliveries: %v\n", err)
+	}
+}
+
+// function to post one signed delivery, anything but 2xx is a failure
+func (d *Dispatcher) post(targetURL, secret, deliveryId, eventType string, body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, targetURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderSignature, Sign(secret, timestamp, body))
+	request.Header.Set(HeaderEventType, eventType)
+	request.Header.Set(HeaderDelivery, deliveryId)
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("endpoint answered %s", response.Status)
+	}
+	return nil
+}
+
+// function to list deliveries which gave up, of one merchant or all when merchantId is empty
+func (d *Dispatcher) DeadLetters(merchantId string) []Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	list := []Delivery{}
+	for _, delivery := range d.deliveries {
+		if delivery.Status == StatusDead && (merchantId == "" || delivery.MerchantId == merchantId) {
+			list = append(list, *delivery)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Id < list[j].Id })
+	return list
+}
+
+// function to send a dead delivery again by hand, it gets a fresh set of attempts
+func (d *Dispatcher) Replay(deliveryId, merchantId string) (Delivery, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delivery, exists := d.deliveries[deliveryId]
+	if !exists || (merchantId != "" && delivery.MerchantId != merchantId) {
+		return Delivery{}, ErrNotFound
+	}
+	if delivery.Status != StatusDead {
+		return *delivery, fmt.Errorf("Delivery %s is still being retried", deliveryId)
+	}
+
+	attempts := delivery.Attempts
+	delivery.Status = StatusPending
+	delivery.Attempts = 0
+	err := d.saveLocked()
+	if err != nil {
+		delivery.Status = StatusDead
+		delivery.Attempts = attempts
+		return *delivery, err
+	}
+	d.scheduleLocked(delivery, 0)
+	return *delivery, nil
+}
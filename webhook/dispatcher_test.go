@@ -0,0 +1,148 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// endpoint answering with the statuses it is given in turn, the last one from then on
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bodies = append(r.bodies, body)
+	r.headers = append(r.headers, req.Header.Clone())
+	code := r.statuses[0]
+	if len(r.statuses) > 1 {
+		r.statuses = r.statuses[1:]
+	}
+	w.WriteHeader(code)
+}
+
+func (r *receiver) received() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.bodies)
+}
+
+func fastOptions() Options {
+	return Options{MaxAttempts: 3, BaseBackoff: 5 * time.Millisecond, MaxBackoff: 20 * time.Millisecond, Timeout: time.Second}
+}
+
+// function to wait until cond holds, failing the test after a while
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDeliveryIsSigned(t *testing.T) {
+	target := &receiver{statuses: []int{http.StatusOK}}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	d := NewDispatcher(fastOptions())
+	endpoint, err := d.AddEndpoint("m_1", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := d.Publish("", EventPaymentCommitted, Payment{TransactionId: "tx-1", MerchantId: "m_1", Amount: 10})
+	if err != nil || count != 1 {
+		t.Fatalf("published to %d endpoints: %v", count, err)
+	}
+	eventually(t, "the delivery", func() bool { return target.received() == 1 })
+
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	header := target.headers[0]
+	err = Verify(endpoint.Secret, header.Get(HeaderTimestamp), header.Get(HeaderSignature), target.bodies[0], time.Minute, time.Now())
+	if err != nil {
+		t.Fatalf("delivery doesn't verify with the endpoint secret: %v", err)
+	}
+	if header.Get(HeaderEventType) != EventPaymentCommitted {
+		t.Fatalf("event header %q", header.Get(HeaderEventType))
+	}
+}
+
+func TestPublishOnlyReachesSubscribedEndpoints(t *testing.T) {
+	d := NewDispatcher(fastOptions())
+	if _, err := d.AddEndpoint("m_1", "http://127.0.0.1:1/a", []string{EventPaymentRefunded}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.AddEndpoint("m_2", "http://127.0.0.1:1/b", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if count, _ := d.Publish("", EventPaymentCommitted, Payment{MerchantId: "m_1"}); count != 0 {
+		t.Errorf("event type the endpoint didn't ask for queued %d deliveries", count)
+	}
+	if count, _ := d.Publish("", EventPaymentCommitted, Payment{}); count != 0 {
+		t.Errorf("payment without merchant queued %d deliveries", count)
+	}
+	if _, err := d.Publish("", "payment.teleported", Payment{MerchantId: "m_1"}); err == nil {
+		t.Error("unknown event type accepted")
+	}
+}
+
+func TestFailedDeliveryBacksOffThenDies(t *testing.T) {
+	target := &receiver{statuses: []int{http.StatusInternalServerError}}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	d := NewDispatcher(fastOptions())
+	if _, err := d.AddEndpoint("m_1", server.URL, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Publish("", EventPaymentAborted, Payment{MerchantId: "m_1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, "the dead letter", func() bool { return len(d.DeadLetters("m_1")) == 1 })
+	dead := d.DeadLetters("m_1")[0]
+	if dead.Attempts != 3 || target.received() != 3 {
+		t.Fatalf("dead after %d attempts and %d posts, want 3", dead.Attempts, target.received())
+	}
+	if len(d.DeadLetters("m_2")) != 0 {
+		t.Fatal("dead letter shown to another merchant")
+	}
+
+	//the endpoint is fixed, a replay goes through and drops the dead letter
+	target.mu.Lock()
+	target.statuses = []int{http.StatusNoContent}
+	target.mu.Unlock()
+	if _, err := d.Replay(dead.Id, "m_2"); err != ErrNotFound {
+		t.Fatalf("replay by another merchant: %v", err)
+	}
+	if _, err := d.Replay(dead.Id, "m_1"); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the replayed delivery", func() bool { return target.received() == 4 })
+	eventually(t, "the dead letter to go", func() bool { return len(d.DeadLetters("")) == 0 })
+}
+
+func TestBackoffDoublesUpToMax(t *testing.T) {
+	d := NewDispatcher(Options{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second})
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, wait := range want {
+		if got := d.backoff(i + 1); got != wait {
+			t.Errorf("wait before retry %d is %v, want %v", i+1, got, wait)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerifyAcceptsSignedDelivery(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"id":"evt_1"}`)
+	signature := Sign("whsec_a", now.Unix(), body)
+
+	err := Verify("whsec_a", strconv.FormatInt(now.Unix(), 10), signature, body, 5*time.Minute, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("signed delivery refused: %v", err)
+	}
+}
+
+func TestVerifyRefusesTampering(t *testing.T) {
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"amount":10}`)
+	signature := Sign("whsec_a", now.Unix(), body)
+
+	cases := map[string]struct {
+		secret, timestamp, signature string
+		body                         []byte
+		at                           time.Time
+	}{
+		"other body":      {"whsec_a", timestamp, signature, []byte(`{"amount":1000}`), now},
+		"other secret":    {"whsec_b", timestamp, signature, body, now},
+		"moved timestamp": {"whsec_a", strconv.FormatInt(now.Unix()+1, 10), signature, body, now},
+		"too old":         {"whsec_a", timestamp, signature, body, now.Add(10 * time.Minute)},
+		"from the future": {"whsec_a", timestamp, signature, body, now.Add(-10 * time.Minute)},
+		"bad timestamp":   {"whsec_a", "yesterday", signature, body, now},
+		"other version":   {"whsec_a", timestamp, "v2=" + signature[len(signatureVersion):], body, now},
+	}
+	for name, c := range cases {
+		if err := Verify(c.secret, c.timestamp, c.signature, c.body, 5*time.Minute, c.at); err == nil {
+			t.Errorf("%s: delivery accepted", name)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// returned when a change could not be written to the state file, nothing was changed
+var ErrNotSaved = errors.New("webhook state not saved")
+
+// a delivery as kept in the state file, with the body it sends
+type savedDelivery struct {
+	Delivery
+	Body []byte
+}
+
+// everything a dispatcher needs after a restart
+type savedState struct {
+	Endpoints  []*Endpoint
+	Deliveries []savedDelivery
+}
+
+// function to open a dispatcher keeping its endpoints and deliveries in path
+// pending deliveries of an earlier run are attempted again once they are due
+func OpenDispatcher(path string, options Options) (*Dispatcher, error) {
+	d := NewDispatcher(options)
+	d.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return d, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read webhook state: %v", err)
+	}
+	var state savedState
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid webhook state in %s: %v", path, err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, endpoint := range state.Endpoints {
+		d.endpoints[endpoint.Id] = endpoint
+	}
+	for _, saved := range state.Deliveries {
+		delivery := saved.Delivery
+		delivery.body = saved.Body
+		d.deliveries[delivery.Id] = &delivery
+		if delivery.Status == StatusPending {
+			d.scheduleLocked(&delivery, time.Until(delivery.NextAttempt))
+		}
+	}
+	return d, nil
+}
+
+// function to write endpoints and deliveries aside and rename them over the state file, caller holds mu
+// a dispatcher without a path keeps everything in memory only
+func (d *Dispatcher) saveLocked() error {
+	if d.path == "" {
+		return nil
+	}
+
+	state := savedState{Endpoints: []*Endpoint{}, Deliveries: []savedDelivery{}}
+	for _, endpoint := range d.endpoints {
+		state.Endpoints = append(state.Endpoints, endpoint)
+	}
+	for _, delivery := range d.deliveries {
+		state.Deliveries = append(state.Deliveries, savedDelivery{Delivery: *delivery, Body: delivery.body})
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotSaved, err)
+	}
+
+	tmp := d.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotSaved, err)
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	file.Close()
+	if err == nil {
+		err = os.Rename(tmp, d.path)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotSaved, err)
+	}
+	return nil
+}
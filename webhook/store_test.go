@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRestartKeepsEndpointsAndDeliveries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+	options := fastOptions()
+	options.BaseBackoff = time.Hour
+	options.MaxBackoff = time.Hour
+
+	//the endpoint fails the first attempt, so the delivery is still pending when the dispatcher stops
+	target := &receiver{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	d, err := OpenDispatcher(path, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoint, err := d.AddEndpoint("m_1", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Publish("evt_1", EventPaymentCommitted, Payment{TransactionId: "tx-1", MerchantId: "m_1"}); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the first attempt", func() bool { return target.received() == 1 })
+	eventually(t, "the failed attempt to be saved", func() bool {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		for _, delivery := range d.deliveries {
+			return delivery.Attempts == 1
+		}
+		return false
+	})
+
+	restarted, err := OpenDispatcher(path, fastOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoints := restarted.Endpoints("m_1")
+	if len(endpoints) != 1 || endpoints[0].Id != endpoint.Id {
+		t.Fatalf("endpoints after restart %v", endpoints)
+	}
+	//the bus hands the event over again as its offset wasn't committed, it isn't queued twice
+	if count, err := restarted.Publish("evt_1", EventPaymentCommitted, Payment{TransactionId: "tx-1", MerchantId: "m_1"}); err != nil || count != 0 {
+		t.Fatalf("redelivered event queued %d deliveries: %v", count, err)
+	}
+
+	//it was due in an hour, bring that forward
+	restarted.mu.Lock()
+	if len(restarted.deliveries) != 1 {
+		restarted.mu.Unlock()
+		t.Fatalf("%d deliveries after restart, want the pending one", len(restarted.deliveries))
+	}
+	for _, delivery := range restarted.deliveries {
+		restarted.scheduleLocked(delivery, 0)
+	}
+	restarted.mu.Unlock()
+
+	eventually(t, "the delivery after restart", func() bool { return target.received() == 2 })
+	target.mu.Lock()
+	body, header := target.bodies[1], target.headers[1]
+	target.mu.Unlock()
+	if err := Verify(endpoint.Secret, header.Get(HeaderTimestamp), header.Get(HeaderSignature), body, time.Minute, time.Now()); err != nil {
+		t.Fatalf("delivery after restart not signed with the saved secret: %v", err)
+	}
+	if string(body) != string(target.bodies[0]) {
+		t.Fatalf("body changed across the restart: %s, then %s", target.bodies[0], body)
+	}
+	eventually(t, "the delivered one to be dropped", func() bool {
+		again, err := OpenDispatcher(path, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again.mu.Lock()
+		defer again.mu.Unlock()
+		return len(again.deliveries) == 0
+	})
+}
+
+func TestNothingQueuedWhenStateCantBeSaved(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "webhooks.json")
+	d, err := OpenDispatcher(path, fastOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.AddEndpoint("m_1", "http://127.0.0.1:1/hook", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	//a directory in its place, so the state file can't be replaced any more
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = d.Publish("evt_1", EventPaymentAborted, Payment{MerchantId: "m_1"})
+	if !errors.Is(err, ErrNotSaved) {
+		t.Fatalf("publish got %v, want ErrNotSaved", err)
+	}
+	d.mu.Lock()
+	queued := len(d.deliveries)
+	d.mu.Unlock()
+	if queued != 0 {
+		t.Fatalf("%d deliveries queued without being saved", queued)
+	}
+	if _, err := d.AddEndpoint("m_1", "http://127.0.0.1:1/other", nil); !errors.Is(err, ErrNotSaved) {
+		t.Fatalf("add endpoint got %v, want ErrNotSaved", err)
+	}
+	if len(d.Endpoints("m_1")) != 1 {
+		t.Fatal("endpoint kept without being saved")
+	}
+}
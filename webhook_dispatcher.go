@@ -0,0 +1,272 @@
+package main
+
+import (
+	authee "assignment_2/auth"
+	"assignment_2/eventbus"
+	pb "assignment_2/proto"
+	"assignment_2/tlsutil"
+	"assignment_2/webhook"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type WebhookServer struct {
+	pb.UnimplementedWebhookServiceServer
+	dispatcher *webhook.Dispatcher
+}
+
+var authLoadBalancerAddressForWebhooks = "localhost:50055"
+
+// every call to other services goes over tls and carries our service token
+var transportSecurity = grpc.WithTransportCredentials(tlsutil.ClientCredentials(authee.ServiceWebhooks))
+var serviceIdentity = grpc.WithPerRPCCredentials(authee.NewServiceCredentials(authee.ServiceWebhooks))
+
+// function to find whose webhooks the caller manages
+// merchants with an api key manage their own, admins name the merchant in the request
+func merchantOf(ctx context.Context, requested string) (string, error) {
+	caller, ok := authee.CallerFromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "Unknown caller")
+	}
+	if caller.MerchantId != "" {
+		if requested != "" && requested != caller.MerchantId {
+			return "", status.Error(codes.PermissionDenied, "Merchants only manage their own webhooks")
+		}
+		return caller.MerchantId, nil
+	}
+	if authee.HasScope(caller.Role, authee.ScopeMerchantsManage) && requested != "" {
+		return requested, nil
+	}
+	return "", status.Error(codes.PermissionDenied, "Call with a merchant api key or name the merchant as admin")
+}
+
+func toDeliveryProto(delivery webhook.Delivery) *pb.WebhookDelivery {
+	return &pb.WebhookDelivery{
+		DeliveryId: delivery.Id,
+		EventId:    delivery.EventId,
+		EventType:  delivery.EventType,
+		EndpointId: delivery.EndpointId,
+		MerchantId: delivery.MerchantId,
+		Status:     delivery.Status,
+		Attempts:   int32(delivery.Attempts),
+		LastError:  delivery.LastError,
+	}
+}
+
+// function to register an endpoint, the signing secret is shown only in this response
+func (ws *WebhookServer) RegisterWebhook(ctx context.Context, req *pb.WebhookEndpoint) (*pb.WebhookEndpoint, error) {
+	merchantId, err := merchantOf(ctx, req.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := ws.dispatcher.AddEndpoint(merchantId, req.Url, req.EventTypes)
+	if errors.Is(err, webhook.ErrNotSaved) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	fmt.Printf("Webhook %s registered for merchant %s: %s\n", endpoint.Id, merchantId, endpoint.URL)
+	return &pb.WebhookEndpoint{
+		EndpointId: endpoint.Id,
+		MerchantId: merchantId,
+		Url:        endpoint.URL,
+		EventTypes: endpoint.EventTypes,
+		Secret:     endpoint.Secret,
+		CreatedAt:  endpoint.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (ws *WebhookServer) ListWebhooks(ctx context.Context, req *pb.MerchantId) (*pb.WebhookEndpointList, error) {
+	merchantId, err := merchantOf(ctx, req.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &pb.WebhookEndpointList{}
+	for _, endpoint := range ws.dispatcher.Endpoints(merchantId) {
+		list.Endpoints = append(list.Endpoints, &pb.WebhookEndpoint{
+			EndpointId: endpoint.Id,
+			MerchantId: endpoint.MerchantId,
+			Url:        endpoint.URL,
+			EventTypes: endpoint.EventTypes,
+			CreatedAt:  endpoint.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return list, nil
+}
+
+func (ws *WebhookServer) DeleteWebhook(ctx context.Context, req *pb.WebhookEndpoint) (*pb.Response, error) {
+	merchantId, err := merchantOf(ctx, req.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ws.dispatcher.RemoveEndpoint(merchantId, req.EndpointId)
+	if errors.Is(err, webhook.ErrNotSaved) {
+		return &pb.Response{Status: "Webhook Not Deleted"}, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return &pb.Response{Status: "Webhook Not Found"}, status.Errorf(codes.NotFound, "Webhook %s not found", req.EndpointId)
+	}
+	return &pb.Response{Status: "Webhook deleted"}, nil
+}
+
+// payment events merchants get webhooks for, the bus carries more kinds than that
+var webhookEvents = map[string]bool{
+	eventbus.PaymentCommitted: true,
+	eventbus.PaymentAborted:   true,
+	eventbus.PaymentRefunded:  true,
+}
+
+// function to queue webhooks for payment events off the bus, as consumer group webhooks
+// the deliveries are saved before the handler returns, so the offset only moves past an event once
+// its webhooks survive a restart. an event handed over again keeps its event id, receivers tell it apart by that
+func (ws *WebhookServer) consumePaymentEvents(bus eventbus.Bus) {
+	err := bus.Subscribe(context.Background(), eventbus.TopicPayments, "webhooks", func(ctx context.Context, event eventbus.Event) error {
+		payment, err := eventbus.DecodePayment(event)
+		if err != nil {
+			fmt.Printf("Skipped event %s, not a payment event: %v\n", event.Id(), err)
+			return nil
+		}
+		if !webhookEvents[payment.Type] {
+			return nil
+		}
+
+		eventId := "evt_" + strings.ReplaceAll(event.Id(), "/", "_")
+		_, err = ws.dispatcher.Publish(eventId, payment.Type, webhook.Payment{
+			TransactionId: payment.TransactionId,
+			SenderId:      payment.SenderId,
+			ReceiverId:    payment.ReceiverId,
+			Amount:        payment.Amount,
+			MerchantId:    payment.MerchantId,
+		})
+		if errors.Is(err, webhook.ErrNotSaved) {
+			//handed over again after a backoff, nothing was queued
+			return err
+		}
+		if err != nil {
+			fmt.Printf("Skipped event %s: %v\n", event.Id(), err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to consume payment events: %v", err)
+	}
+}
+
+func (ws *WebhookServer) ListDeadLetters(ctx context.Context, req *pb.MerchantId) (*pb.WebhookDeliveryList, error) {
+	merchantId, err := merchantOf(ctx, req.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &pb.WebhookDeliveryList{}
+	for _, delivery := range ws.dispatcher.DeadLetters(merchantId) {
+		list.Deliveries = append(list.Deliveries, toDeliveryProto(delivery))
+	}
+	return list, nil
+}
+
+// function to send a dead delivery again by hand
+func (ws *WebhookServer) ReplayDelivery(ctx context.Context, req *pb.WebhookDelivery) (*pb.WebhookDelivery, error) {
+	merchantId, err := merchantOf(ctx, req.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := ws.dispatcher.Replay(req.DeliveryId, merchantId)
+	if errors.Is(err, webhook.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Delivery %s not found", req.DeliveryId)
+	}
+	if errors.Is(err, webhook.ErrNotSaved) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	fmt.Printf("Webhook delivery %s replayed for merchant %s\n", delivery.Id, merchantId)
+	return toDeliveryProto(delivery), nil
+}
+
+// function to read retry settings from env, WEBHOOK_MAX_ATTEMPTS and WEBHOOK_BASE_BACKOFF (e.g. "2s")
+func webhookOptions() webhook.Options {
+	options := webhook.DefaultOptions()
+
+	if value := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); value != "" {
+		attempts, err := strconv.Atoi(value)
+		if err != nil || attempts < 1 {
+			log.Fatalf("Invalid WEBHOOK_MAX_ATTEMPTS %q", value)
+		}
+		options.MaxAttempts = attempts
+	}
+	if value := os.Getenv("WEBHOOK_BASE_BACKOFF"); value != "" {
+		backoff, err := time.ParseDuration(value)
+		if err != nil || backoff <= 0 {
+			log.Fatalf("Invalid WEBHOOK_BASE_BACKOFF %q", value)
+		}
+		options.BaseBackoff = backoff
+	}
+	return options
+}
+
+// function to find where endpoints and deliveries are kept, WEBHOOK_STATE_FILE moves it
+func webhookStatePath() string {
+	path := os.Getenv("WEBHOOK_STATE_FILE")
+	if path == "" {
+		path = "webhooks.json"
+	}
+	return path
+}
+
+func main() {
+	//service tokens can't be signed or checked without the shared secret
+	if err := authee.LoadServiceSecret(); err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	listen, err := net.Listen("tcp", ":50060")
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	//merchants manage their webhooks with their api key
+	authee.EnableAPIKeys(authee.NewRemoteAPIKeyVerifier(authLoadBalancerAddressForWebhooks, transportSecurity, serviceIdentity))
+
+	grpcServer := grpc.NewServer(grpc.Creds(tlsutil.ServerCredentials(authee.ServiceWebhooks)), grpc.UnaryInterceptor(authee.AuthInterceptor), grpc.StreamInterceptor(authee.StreamAuthInterceptor))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	dispatcher, err := webhook.OpenDispatcher(webhookStatePath(), webhookOptions())
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	webhookServer := &WebhookServer{dispatcher: dispatcher}
+	pb.RegisterWebhookServiceServer(grpcServer, webhookServer)
+
+	bus, err := eventbus.OpenDefault()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	go webhookServer.consumePaymentEvents(bus)
+
+	fmt.Println("Webhook Dispatcher running on port 50060...")
+	err = grpcServer.Serve(listen)
+	if err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}